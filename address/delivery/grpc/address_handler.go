@@ -2,18 +2,20 @@ package grpc
 
 import (
 	"context"
-	"errors"
 
 	"github.com/google/uuid"
 	"github.com/imimran/go-grpc-auth/address/domain"
 	transformer "github.com/imimran/go-grpc-auth/address/transformer/grpc"
 	"github.com/imimran/go-grpc-auth/address/usecase"
+	"github.com/imimran/go-grpc-auth/apperr"
+	"github.com/imimran/go-grpc-auth/auth"
+	paging "github.com/imimran/go-grpc-auth/pagination"
 	pb "github.com/imimran/go-grpc-auth/proto" // Ensure this matches your pb package path
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"gorm.io/gorm"
+	userDomain "github.com/imimran/go-grpc-auth/user/domain"
 )
 
+var errUnauthenticated = apperr.Unauthenticated("UNAUTHENTICATED", "authentication required")
+
 type AddressHandler struct {
 	addressUC *usecase.AddressUsecase
 	pb.UnimplementedAddressServiceServer
@@ -23,23 +25,66 @@ func NewAddressHandler(addressUC *usecase.AddressUsecase) *AddressHandler {
 	return &AddressHandler{addressUC: addressUC}
 }
 
-func (h *AddressHandler) ListAddress(ctx context.Context, req *pb.AddressListRequest) (*pb.AddressListResponse, error) {
-	// 1. Pagination defaults
-	page := int(req.GetPage())
+// requester builds the usecase caller from the verified token claims
+func requester(ctx context.Context) (domain.Requester, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return domain.Requester{}, errUnauthenticated
+	}
+	return domain.Requester{
+		UserID:  claims.UserID,
+		IsAdmin: claims.HasPermission(userDomain.PermAddressesManage),
+	}, nil
+}
+
+// pagination applies the list defaults shared by every list RPC
+func pagination(page, limit int32) (int, int) {
 	if page <= 0 {
 		page = 1
 	}
-	limit := int(req.GetLimit())
 	if limit <= 0 {
 		limit = 10
 	}
+	return int(page), int(limit)
+}
+
+func (h *AddressHandler) ListAddress(ctx context.Context, req *pb.AddressListRequest) (*pb.AddressListResponse, error) {
+	caller, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Cursor or offset page
+	query := paging.Query{
+		Page:         int(req.GetPage()),
+		Limit:        int(req.GetLimit()),
+		Token:        req.GetPageToken(),
+		IncludeTotal: req.IncludeTotal,
+	}
 
 	// 2. Fetch from Usecase
-	addresses, total, err := h.addressUC.List(ctx, page, limit)
+	result, err := h.addressUC.List(ctx, caller, transformer.ToDomainAddressFilter(req), query)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toListResponse(result.Items, result.Total, result.Page)
+	resp.NextPageToken = result.NextPageToken
+	return resp, nil
+}
+
+func (h *AddressHandler) ListUserAddresses(ctx context.Context, req *pb.ListUserAddressesRequest) (*pb.AddressListResponse, error) {
+	page, limit := pagination(req.GetPage(), req.GetLimit())
+
+	addresses, total, err := h.addressUC.ListByUser(ctx, req.GetUserId(), page, limit)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to fetch addresses: %v", err)
+		return nil, err
 	}
 
+	return toListResponse(addresses, total, page), nil
+}
+
+func toListResponse(addresses []domain.Address, total int64, page int) *pb.AddressListResponse {
 	// 3. Map Domain to Proto
 	var pbAddresses []*pb.Address
 	for i := range addresses {
@@ -56,23 +101,31 @@ func (h *AddressHandler) ListAddress(ctx context.Context, req *pb.AddressListReq
 		},
 		Total: total,
 		Page:  int32(page),
-	}, nil
+	}
 }
 
 func (h *AddressHandler) CreateAddress(ctx context.Context, req *pb.CreateAddressRequest) (*pb.AddressResponse, error) {
-	// Map request to Domain model using the nested Coordinates struct
+	caller, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map request to Domain model using the nested Coordinates struct.
+	// UserID is only honoured for admins, see AddressUsecase.Create.
 	addr := &domain.Address{
+		UserID:     req.UserId,
 		RawAddress: req.RawAddress,
 		Coordinates: domain.Coordinates{
 			Latitude:  req.Coordinates.GetLatitude(),
 			Longitude: req.Coordinates.GetLongitude(),
 		},
-		Accuracy: req.Accuracy,
-		Source:   req.Source,
+		Accuracy:   req.Accuracy,
+		Source:     req.Source,
+		Components: transformer.ToDomainComponents(req.Components),
 	}
 
-	if err := h.addressUC.Create(ctx, addr); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create: %v", err)
+	if err := h.addressUC.Create(ctx, caller, addr); err != nil {
+		return nil, err
 	}
 
 	return &pb.AddressResponse{
@@ -83,14 +136,14 @@ func (h *AddressHandler) CreateAddress(ctx context.Context, req *pb.CreateAddres
 }
 
 func (h *AddressHandler) GetAddress(ctx context.Context, req *pb.AddressId) (*pb.AddressResponse, error) {
-	address, err := h.addressUC.GetByID(ctx, req.GetId())
+	caller, err := requester(ctx)
 	if err != nil {
-		// Check if the error is "Record Not Found"
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, status.Error(codes.NotFound, "Address not found in database")
-		}
-		// Other unexpected errors remain Internal
-		return nil, status.Errorf(codes.Internal, "Database error: %v", err)
+		return nil, err
+	}
+
+	address, err := h.addressUC.GetByID(ctx, caller, req.GetId())
+	if err != nil {
+		return nil, err
 	}
 
 	return &pb.AddressResponse{
@@ -101,10 +154,15 @@ func (h *AddressHandler) GetAddress(ctx context.Context, req *pb.AddressId) (*pb
 }
 
 func (h *AddressHandler) UpdateAddress(ctx context.Context, req *pb.UpdateAddressRequest) (*pb.AddressResponse, error) {
+	caller, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// 1. Parse string ID to uuid.UUID
 	parsedID, err := uuid.Parse(req.Id)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid uuid format: %v", err)
+		return nil, domain.ErrInvalidAddressID
 	}
 
 	// 2. Map request to Domain model
@@ -115,13 +173,14 @@ func (h *AddressHandler) UpdateAddress(ctx context.Context, req *pb.UpdateAddres
 			Latitude:  req.Coordinates.GetLatitude(),
 			Longitude: req.Coordinates.GetLongitude(),
 		},
-		Accuracy: req.Accuracy,
-		Source:   req.Source,
+		Accuracy:   req.Accuracy,
+		Source:     req.Source,
+		Components: transformer.ToDomainComponents(req.Components),
 	}
 
-	// 3. Call Usecase
-	if err := h.addressUC.Update(ctx, addr); err != nil {
-		return nil, status.Errorf(codes.Internal, "update failed: %v", err)
+	// 3. Call Usecase; with a mask only the listed fields change
+	if err := h.addressUC.Update(ctx, caller, addr, req.GetUpdateMask().GetPaths()); err != nil {
+		return nil, err
 	}
 
 	return &pb.AddressResponse{
@@ -132,7 +191,12 @@ func (h *AddressHandler) UpdateAddress(ctx context.Context, req *pb.UpdateAddres
 }
 
 func (h *AddressHandler) DeleteAddress(ctx context.Context, req *pb.AddressId) (*pb.DeleteAddressResponse, error) {
-	err := h.addressUC.Delete(ctx, req.Id)
+	caller, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.addressUC.Delete(ctx, caller, req.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -142,3 +206,137 @@ func (h *AddressHandler) DeleteAddress(ctx context.Context, req *pb.AddressId) (
 		Message: "Address deleted successfully",
 	}, nil
 }
+
+func (h *AddressHandler) SearchAddressesNearby(ctx context.Context, req *pb.SearchAddressesNearbyRequest) (*pb.SearchAddressesNearbyResponse, error) {
+	caller, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// center and radius_meters are checked by their validate rules
+	center := domain.Coordinates{
+		Latitude:  req.Center.GetLatitude(),
+		Longitude: req.Center.GetLongitude(),
+	}
+
+	results, err := h.addressUC.SearchNearby(ctx, caller, center, req.GetRadiusMeters(), int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SearchAddressesNearbyResponse{
+		Success: true,
+		Message: "Addresses retrieved successfully",
+		Results: transformer.ToProtoNearbyAddressList(results),
+	}, nil
+}
+
+func (h *AddressHandler) ReverseGeocode(ctx context.Context, req *pb.ReverseGeocodeRequest) (*pb.ReverseGeocodeResponse, error) {
+	caller, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	point := transformer.ToDomainCoordinates(req.Point)
+
+	result, err := h.addressUC.ReverseGeocode(ctx, caller, point, req.GetToleranceMeters())
+	if err != nil {
+		return nil, err
+	}
+
+	return transformer.ToProtoReverseGeocodeResponse(result), nil
+}
+
+func (h *AddressHandler) ListAddressesInBoundingBox(ctx context.Context, req *pb.ListAddressesInBoundingBoxRequest) (*pb.AddressListResponse, error) {
+	caller, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	box := domain.BoundingBox{
+		SouthWest: transformer.ToDomainCoordinates(req.SouthWest),
+		NorthEast: transformer.ToDomainCoordinates(req.NorthEast),
+	}
+	page, limit := pagination(req.GetPage(), req.GetLimit())
+
+	addresses, total, err := h.addressUC.ListInBoundingBox(ctx, caller, box, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toAreaResponse(addresses, total, page), nil
+}
+
+func (h *AddressHandler) ListAddressesInPolygon(ctx context.Context, req *pb.ListAddressesInPolygonRequest) (*pb.AddressListResponse, error) {
+	caller, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var polygon domain.Polygon
+	switch p := req.Polygon.(type) {
+	case *pb.ListAddressesInPolygonRequest_Geojson:
+		polygon, err = domain.ParseGeoJSONPolygon([]byte(p.Geojson))
+		if err != nil {
+			return nil, err
+		}
+	case *pb.ListAddressesInPolygonRequest_Points:
+		points := make([]domain.Coordinates, 0, len(p.Points.GetPoints()))
+		for _, c := range p.Points.GetPoints() {
+			points = append(points, transformer.ToDomainCoordinates(c))
+		}
+		polygon = domain.NewPolygon(points)
+	default:
+		return nil, apperr.InvalidField("polygon", "geojson or points is required")
+	}
+	page, limit := pagination(req.GetPage(), req.GetLimit())
+
+	addresses, total, err := h.addressUC.ListInPolygon(ctx, caller, polygon, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toAreaResponse(addresses, total, page), nil
+}
+
+func toAreaResponse(addresses []domain.Address, total int64, page int) *pb.AddressListResponse {
+	resp := toListResponse(addresses, total, page)
+	if total > usecase.MaxAreaResults {
+		resp.Total = usecase.MaxAreaResults
+		resp.Truncated = true
+	}
+	return resp
+}
+
+func (h *AddressHandler) FindDuplicateAddresses(ctx context.Context, req *pb.FindDuplicateAddressesRequest) (*pb.FindDuplicateAddressesResponse, error) {
+	clusters, err := h.addressUC.FindDuplicates(ctx, domain.DuplicateQuery{
+		MinSimilarity:     req.GetMinSimilarity(),
+		MaxDistanceMeters: req.GetMaxDistanceMeters(),
+		UserID:            req.GetUserId(),
+	}, int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.FindDuplicateAddressesResponse{
+		Success:  true,
+		Message:  "Duplicate clusters retrieved successfully",
+		Clusters: transformer.ToProtoDuplicateClusters(clusters),
+	}, nil
+}
+
+func (h *AddressHandler) MergeAddresses(ctx context.Context, req *pb.MergeAddressesRequest) (*pb.AddressResponse, error) {
+	caller, err := requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	survivor, err := h.addressUC.Merge(ctx, caller, req.GetSurvivorId(), req.GetDuplicateIds())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AddressResponse{
+		Success: true,
+		Message: "Addresses merged successfully",
+		Data:    transformer.ToProtoAddress(survivor),
+	}, nil
+}
@@ -0,0 +1,71 @@
+package grpc
+
+import (
+	"bufio"
+
+	"github.com/imimran/go-grpc-auth/address/exporter"
+	transformer "github.com/imimran/go-grpc-auth/address/transformer/grpc"
+	"github.com/imimran/go-grpc-auth/address/usecase"
+	"github.com/imimran/go-grpc-auth/apperr"
+	pb "github.com/imimran/go-grpc-auth/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize is the payload size of each streamed message
+const exportChunkSize = 32 * 1024
+
+// ExportAddresses encodes every matching address and streams the file in
+// chunks as it is produced.
+func (h *AddressHandler) ExportAddresses(req *pb.ExportAddressesRequest, stream grpc.ServerStreamingServer[pb.ExportAddressesResponse]) error {
+	ctx := stream.Context()
+	caller, err := requester(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 1. Encoder writing through a buffer that sends full chunks
+	out := &chunkSender{stream: stream, format: req.GetFormat()}
+	buf := bufio.NewWriterSize(out, exportChunkSize)
+	w, err := exporter.NewWriter(req.GetFormat(), buf)
+	if err != nil {
+		return apperr.InvalidField("format", err.Error())
+	}
+
+	// 2. Walk the matching rows
+	err = h.addressUC.Export(ctx, caller, transformer.ToDomainExportFilter(req), usecase.DefaultExportBatchSize, w.Write)
+	if err == nil {
+		err = w.Close()
+	}
+	if err == nil {
+		err = buf.Flush()
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// chunkSender sends each write as one message, with the content type and
+// file name on the first.
+type chunkSender struct {
+	stream grpc.ServerStreamingServer[pb.ExportAddressesResponse]
+	format string
+	sent   bool
+}
+
+func (c *chunkSender) Write(p []byte) (int, error) {
+	msg := &pb.ExportAddressesResponse{Chunk: p}
+	if !c.sent {
+		msg.ContentType = exporter.ContentType(c.format)
+		msg.FileName = exporter.FileName(c.format)
+		c.sent = true
+	}
+	if err := c.stream.Send(msg); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
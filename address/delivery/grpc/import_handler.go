@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"errors"
+	"io"
+	"log"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+	"github.com/imimran/go-grpc-auth/address/importer"
+	"github.com/imimran/go-grpc-auth/address/usecase"
+	"github.com/imimran/go-grpc-auth/apperr"
+	pb "github.com/imimran/go-grpc-auth/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// ImportAddresses streams the uploaded file straight into the importer; the
+// whole file is never held in memory.
+func (h *AddressHandler) ImportAddresses(stream grpc.ClientStreamingServer[pb.ImportAddressesRequest, pb.ImportAddressesResponse]) error {
+	ctx := stream.Context()
+	caller, err := requester(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 1. The first message must carry the options
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return apperr.InvalidField("options", "options message is required")
+	}
+	if err != nil {
+		return err
+	}
+	opts := first.GetOptions()
+	if opts == nil {
+		return apperr.InvalidField("options", "the first message must carry options")
+	}
+
+	// 2. Pipe the chunks to the reader as they arrive
+	pr, pw := io.Pipe()
+	defer pr.Close() // unblocks the receiver if we stop reading early
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err == io.EOF {
+				pw.Close()
+				return
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if msg.GetOptions() != nil {
+				pw.CloseWithError(errors.New("options may only be sent in the first message"))
+				return
+			}
+			if _, err := pw.Write(msg.GetChunk()); err != nil {
+				return
+			}
+		}
+	}()
+
+	reader, err := importer.NewReader(opts.GetFormat(), pr)
+	if err != nil {
+		return apperr.InvalidField("options.format", err.Error())
+	}
+
+	// 3. Import, collecting the per-row report
+	resp := &pb.ImportAddressesResponse{Success: true, Message: "Addresses imported"}
+	summary, err := h.addressUC.Import(ctx, caller, reader, usecase.ImportOptions{Geocode: opts.GetGeocode()}, func(r domain.ImportResult) {
+		if opts.GetErrorsOnly() && r.Status == domain.ImportCreated {
+			return
+		}
+		row := &pb.ImportRowResult{Line: int32(r.Line), Status: r.Status, Reason: r.Reason}
+		if r.Status == domain.ImportCreated {
+			row.AddressId = r.AddressID.String()
+		}
+		resp.Rows = append(resp.Rows, row)
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		// Earlier batches are committed, so report them along with the failure
+		resp.Success = false
+		if errors.Is(err, domain.ErrUnreadableImport) {
+			resp.Message = "Import stopped: " + err.Error()
+		} else {
+			log.Printf("ImportAddresses failed: %v", err)
+			resp.Message = "Import stopped by an internal error"
+		}
+	}
+
+	resp.Created = int32(summary.Created)
+	resp.Duplicates = int32(summary.Duplicates)
+	resp.Rejected = int32(summary.Rejected)
+	return stream.SendAndClose(resp)
+}
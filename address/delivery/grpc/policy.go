@@ -0,0 +1,16 @@
+package grpc
+
+import (
+	"github.com/imimran/go-grpc-auth/auth"
+	pb "github.com/imimran/go-grpc-auth/proto"
+	userDomain "github.com/imimran/go-grpc-auth/user/domain"
+)
+
+// Policy declares the permission each AddressService RPC requires.
+// The remaining RPCs are open to any authenticated user and scoped to the
+// caller's own addresses by the usecase.
+var Policy = auth.Policy{
+	pb.AddressService_ListUserAddresses_FullMethodName:      {Permission: userDomain.PermAddressesManage},
+	pb.AddressService_FindDuplicateAddresses_FullMethodName: {Permission: userDomain.PermAddressesManage},
+	pb.AddressService_MergeAddresses_FullMethodName:         {Permission: userDomain.PermAddressesManage},
+}
@@ -0,0 +1,105 @@
+// Package http serves address endpoints that do not fit grpc-gateway's JSON
+// mapping. Handlers call the gRPC server like the gateway does, so
+// authentication, RBAC and scoping are enforced in one place.
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/imimran/go-grpc-auth/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ExportPath is the download route for ExportAddresses
+const ExportPath = "/v1/addresses:export"
+
+// NewExportHandler serves ExportAddresses as a file download, e.g.
+//
+//	GET /v1/addresses:export?format=csv&country_code=US
+//
+// The query takes the ExportAddressesRequest field names.
+func NewExportHandler(client pb.AddressServiceClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 1. Build the request from the query string
+		q := r.URL.Query()
+		req := &pb.ExportAddressesRequest{
+			Format:      q.Get("format"),
+			HouseNumber: q.Get("house_number"),
+			Street:      q.Get("street"),
+			Unit:        q.Get("unit"),
+			Locality:    q.Get("locality"),
+			Region:      q.Get("region"),
+			PostalCode:  q.Get("postal_code"),
+			CountryCode: q.Get("country_code"),
+		}
+		if v := q.Get("user_id"); v != "" {
+			id, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				writeError(w, status.Error(codes.InvalidArgument, "user_id must be an integer"))
+				return
+			}
+			req.UserId = id
+		}
+
+		// 2. Forward the caller's token the way grpc-gateway does
+		ctx := r.Context()
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", auth)
+		}
+
+		stream, err := client.ExportAddresses(ctx, req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		// 3. Errors before the first chunk still get a proper status
+		first, err := stream.Recv()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", first.GetContentType())
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": first.GetFileName()}))
+		w.WriteHeader(http.StatusOK)
+
+		// 4. Copy the rest as it arrives
+		for msg := first; ; {
+			if _, err := w.Write(msg.GetChunk()); err != nil {
+				return // client went away
+			}
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+			msg, err = stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				// Too late for a status code; abort so the client sees a
+				// truncated transfer instead of a short but valid-looking file
+				log.Printf("Export download failed: %v", err)
+				panic(http.ErrAbortHandler)
+			}
+		}
+	})
+}
+
+// writeError answers with the HTTP status grpc-gateway would use for err
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(st.Code()))
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"code":    int32(st.Code()),
+		"message": st.Message(),
+	})
+}
@@ -1,6 +1,18 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/imimran/go-grpc-auth/apperr"
+)
+
+var (
+    // ErrAddressNotFound is also returned for addresses the requester may not
+    // see, so their existence is not leaked
+    ErrAddressNotFound  = apperr.NotFound("ADDRESS_NOT_FOUND", "address not found")
+    ErrInvalidAddressID = apperr.InvalidArgument("INVALID_ADDRESS_ID", "address id must be a UUID").WithField("id", "must be a UUID")
+)
 
 // Coordinates groups the spatial data
 type Coordinates struct {
@@ -8,12 +20,49 @@ type Coordinates struct {
     Longitude float64 `json:"longitude"`
 }
 
+// Valid reports whether the point lies within WGS84 latitude/longitude ranges
+func (c Coordinates) Valid() bool {
+    return c.Latitude >= -90 && c.Latitude <= 90 &&
+        c.Longitude >= -180 && c.Longitude <= 180
+}
+
 type Address struct {
     ID                uuid.UUID `gorm:"type:uuid;primaryKey"`
+    UserID            int64     `gorm:"index;uniqueIndex:idx_addresses_user_normalized_address,priority:1"` // owner, FK to users(id)
     RawAddress        string
-    NormalizedAddress string      `gorm:"uniqueIndex"`
+    NormalizedAddress string      `gorm:"uniqueIndex:idx_addresses_user_normalized_address,priority:2"` // unique per owner
+    NormalizerVersion int         // normalize.Version that produced NormalizedAddress; 0 = legacy
+    NormalizerLocale  string      `gorm:"type:varchar(8);not null;default:''"` // locale of the rules that produced it; "" = unknown
     Coordinates       Coordinates `gorm:"embedded"` // This groups them in Go
     Accuracy          string
-    Source            string
+    Source            string      // SourceClient or SourceGeocoder
+    GeocodedBy        string      // provider that resolved the coordinates, if geocoded
+    Components        Components  `gorm:"embedded"`
     Geom              string      `gorm:"type:geography(Point,4326)"`
-}
\ No newline at end of file
+    CreatedAt         time.Time
+}
+
+// Requester identifies who is calling the address usecase. Non-admin
+// requesters only ever see and modify their own addresses.
+type Requester struct {
+    UserID  int64
+    IsAdmin bool
+}
+
+// CanAccess reports whether the requester may read or modify addr.
+func (r Requester) CanAccess(addr *Address) bool {
+    return r.IsAdmin || addr.UserID == r.UserID
+}
+
+// AddressFilter narrows List queries. Zero values mean "no filter".
+// Component fields match case-insensitively.
+type AddressFilter struct {
+    UserID     int64
+    Components Components
+}
+
+// NearbyAddress is a search hit together with its distance from the search center.
+type NearbyAddress struct {
+    Address
+    DistanceMeters float64
+}
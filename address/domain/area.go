@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/imimran/go-grpc-auth/apperr"
+)
+
+// MaxPolygonVertices keeps validation (O(n²) self-intersection check) and
+// the resulting query cheap.
+const MaxPolygonVertices = 1000
+
+var ErrInvalidGeometry = apperr.InvalidArgument("INVALID_GEOMETRY", "invalid geometry")
+
+func invalidGeometry(format string, args ...interface{}) error {
+	return ErrInvalidGeometry.WithMessage("invalid geometry: %s", fmt.Sprintf(format, args...))
+}
+
+// BoundingBox is a map viewport. When SouthWest.Longitude is greater than
+// NorthEast.Longitude the box crosses the antimeridian.
+type BoundingBox struct {
+	SouthWest Coordinates
+	NorthEast Coordinates
+}
+
+func (b BoundingBox) Validate() error {
+	if !b.SouthWest.Valid() || !b.NorthEast.Valid() {
+		return invalidGeometry("bounding box corners must be valid latitude/longitude")
+	}
+	if b.SouthWest.Latitude >= b.NorthEast.Latitude {
+		return invalidGeometry("south_west latitude must be below north_east latitude")
+	}
+	if b.SouthWest.Longitude == b.NorthEast.Longitude {
+		return invalidGeometry("bounding box has zero width")
+	}
+	return nil
+}
+
+// Envelopes splits the box at the antimeridian so each part can be expressed
+// as a regular min/max envelope.
+func (b BoundingBox) Envelopes() []BoundingBox {
+	if b.SouthWest.Longitude < b.NorthEast.Longitude {
+		return []BoundingBox{b}
+	}
+	return []BoundingBox{
+		{SouthWest: b.SouthWest, NorthEast: Coordinates{Latitude: b.NorthEast.Latitude, Longitude: 180}},
+		{SouthWest: Coordinates{Latitude: b.SouthWest.Latitude, Longitude: -180}, NorthEast: b.NorthEast},
+	}
+}
+
+// Polygon is an outer ring optionally followed by holes. Rings are closed
+// (first point == last point).
+type Polygon struct {
+	Rings [][]Coordinates
+}
+
+// NewPolygon builds a single-ring polygon from a list of points, closing
+// the ring if the caller did not repeat the first point.
+func NewPolygon(points []Coordinates) Polygon {
+	ring := append([]Coordinates(nil), points...)
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		ring = append(ring, ring[0])
+	}
+	return Polygon{Rings: [][]Coordinates{ring}}
+}
+
+// ParseGeoJSONPolygon accepts a GeoJSON Polygon geometry, or a Feature
+// wrapping one. Positions are [longitude, latitude].
+func ParseGeoJSONPolygon(data []byte) (Polygon, error) {
+	var obj struct {
+		Type        string          `json:"type"`
+		Coordinates [][][]float64   `json:"coordinates"`
+		Geometry    json.RawMessage `json:"geometry"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return Polygon{}, invalidGeometry("malformed GeoJSON: %v", err)
+	}
+
+	switch obj.Type {
+	case "Feature":
+		if len(obj.Geometry) == 0 {
+			return Polygon{}, invalidGeometry("feature has no geometry")
+		}
+		return ParseGeoJSONPolygon(obj.Geometry)
+	case "Polygon":
+	default:
+		return Polygon{}, invalidGeometry("GeoJSON type must be Polygon, got %q", obj.Type)
+	}
+
+	poly := Polygon{}
+	for _, ring := range obj.Coordinates {
+		points := make([]Coordinates, 0, len(ring))
+		for _, pos := range ring {
+			if len(pos) < 2 {
+				return Polygon{}, invalidGeometry("position needs longitude and latitude")
+			}
+			points = append(points, Coordinates{Longitude: pos[0], Latitude: pos[1]})
+		}
+		poly.Rings = append(poly.Rings, points)
+	}
+	return poly, nil
+}
+
+// Validate rejects polygons PostGIS would consider invalid (open, degenerate
+// or self-intersecting rings) before they reach the database.
+func (p Polygon) Validate() error {
+	if len(p.Rings) == 0 {
+		return invalidGeometry("polygon has no rings")
+	}
+
+	total := 0
+	for i, ring := range p.Rings {
+		total += len(ring)
+		if total > MaxPolygonVertices {
+			return invalidGeometry("polygon exceeds %d vertices", MaxPolygonVertices)
+		}
+		if err := validateRing(i, ring); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRing(index int, ring []Coordinates) error {
+	if len(ring) < 4 {
+		return invalidGeometry("ring %d: a ring needs at least 3 distinct points", index)
+	}
+	if ring[0] != ring[len(ring)-1] {
+		return invalidGeometry("ring %d: ring is not closed", index)
+	}
+	for _, c := range ring {
+		if !c.Valid() {
+			return invalidGeometry("ring %d: point (%g, %g) is out of range", index, c.Latitude, c.Longitude)
+		}
+	}
+	// Compare every pair of non-adjacent edges
+	n := len(ring) - 1
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if j == i+1 || (i == 0 && j == n-1) {
+				continue
+			}
+			if segmentsIntersect(ring[i], ring[i+1], ring[j], ring[j+1]) {
+				return invalidGeometry("ring %d: ring self-intersects", index)
+			}
+		}
+	}
+
+	if math.Abs(signedArea(ring)) < 1e-12 {
+		return invalidGeometry("ring %d: ring has zero area", index)
+	}
+	return nil
+}
+
+// WKT renders the polygon as Well-Known Text (longitude first).
+func (p Polygon) WKT() string {
+	var b strings.Builder
+	b.WriteString("POLYGON(")
+	for i, ring := range p.Rings {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("(")
+		for j, c := range ring {
+			if j > 0 {
+				b.WriteString(",")
+			}
+			fmt.Fprintf(&b, "%v %v", c.Longitude, c.Latitude)
+		}
+		b.WriteString(")")
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+const earthRadiusMeters = 6_371_008.8
+
+// DistanceTo returns the great-circle (haversine) distance to o in meters.
+func (c Coordinates) DistanceTo(o Coordinates) float64 {
+	lat1 := c.Latitude * math.Pi / 180
+	lat2 := o.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (o.Longitude - c.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func signedArea(ring []Coordinates) float64 {
+	area := 0.0
+	for i := 0; i < len(ring)-1; i++ {
+		area += ring[i].Longitude*ring[i+1].Latitude - ring[i+1].Longitude*ring[i].Latitude
+	}
+	return area / 2
+}
+
+func orientation(a, b, c Coordinates) float64 {
+	return (b.Longitude-a.Longitude)*(c.Latitude-a.Latitude) - (b.Latitude-a.Latitude)*(c.Longitude-a.Longitude)
+}
+
+func onSegment(a, b, p Coordinates) bool {
+	return math.Min(a.Longitude, b.Longitude) <= p.Longitude && p.Longitude <= math.Max(a.Longitude, b.Longitude) &&
+		math.Min(a.Latitude, b.Latitude) <= p.Latitude && p.Latitude <= math.Max(a.Latitude, b.Latitude)
+}
+
+func segmentsIntersect(p1, p2, q1, q2 Coordinates) bool {
+	d1 := orientation(q1, q2, p1)
+	d2 := orientation(q1, q2, p2)
+	d3 := orientation(p1, p2, q1)
+	d4 := orientation(p1, p2, q2)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(q1, q2, p1)) || (d2 == 0 && onSegment(q1, q2, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, q1)) || (d4 == 0 && onSegment(p1, p2, q2))
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/imimran/go-grpc-auth/apperr"
+)
+
+var ErrInvalidCountryCode = apperr.InvalidArgument("INVALID_COUNTRY_CODE", "country_code must be an ISO 3166-1 alpha-2 code").
+	WithField("components.country_code", "must be an ISO 3166-1 alpha-2 code")
+
+// Components are the structured parts of an address. Any of them may be
+// empty when neither the client nor the parser could tell.
+type Components struct {
+	HouseNumber string
+	Street      string
+	Unit        string
+	Locality    string // city / town
+	Region      string // state / province
+	PostalCode  string
+	CountryCode string // ISO 3166-1 alpha-2, upper case
+}
+
+// Clean trims every field, upper-cases postal and country codes and checks
+// the country code's shape.
+func (c *Components) Clean() error {
+	for _, f := range []*string{&c.HouseNumber, &c.Street, &c.Unit, &c.Locality, &c.Region, &c.PostalCode, &c.CountryCode} {
+		*f = strings.Join(strings.Fields(*f), " ")
+	}
+	c.PostalCode = strings.ToUpper(c.PostalCode)
+	c.CountryCode = strings.ToUpper(c.CountryCode)
+
+	if c.CountryCode != "" {
+		if len(c.CountryCode) != 2 || c.CountryCode[0] < 'A' || c.CountryCode[0] > 'Z' || c.CountryCode[1] < 'A' || c.CountryCode[1] > 'Z' {
+			return ErrInvalidCountryCode
+		}
+	}
+	return nil
+}
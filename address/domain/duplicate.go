@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/imimran/go-grpc-auth/apperr"
+)
+
+var (
+	// ErrDuplicateAddress is returned when a write collides with the unique
+	// index on (user_id, normalized_address): the owner already has it.
+	ErrDuplicateAddress = apperr.AlreadyExists("DUPLICATE_ADDRESS", "the owner already has an address with the same normalized form")
+	// ErrInvalidMerge rejects merges without duplicates, that list the
+	// survivor among them or that span several owners.
+	ErrInvalidMerge = apperr.InvalidArgument("INVALID_MERGE", "invalid merge request")
+	// ErrMergeConflict means a concurrent write made the merge collide with
+	// another address; retrying usually succeeds.
+	ErrMergeConflict = apperr.Conflict("MERGE_CONFLICT", "merge conflicted with a concurrent change, retry")
+)
+
+// DuplicateQuery tunes fuzzy duplicate detection. Two addresses are
+// duplicates when their normalized text is at least MinSimilarity alike
+// (pg_trgm similarity, 0..1) and they lie within MaxDistanceMeters.
+type DuplicateQuery struct {
+	MinSimilarity     float64
+	MaxDistanceMeters float64
+	UserID            int64 // optional owner filter
+	MaxPairs          int
+}
+
+// DuplicatePair is one matching pair found by the repository.
+type DuplicatePair struct {
+	A, B           uuid.UUID
+	Similarity     float64
+	DistanceMeters float64
+}
+
+// DuplicateCluster groups addresses connected by duplicate pairs.
+type DuplicateCluster struct {
+	Addresses         []Address
+	MinSimilarity     float64 // weakest pair in the cluster
+	MaxDistanceMeters float64 // farthest pair in the cluster
+}
+
+// AddressMerge records that MergedID was folded into SurvivorID. Lookups of
+// a merged id are redirected to the survivor through this table.
+type AddressMerge struct {
+	ID           int64     `gorm:"primaryKey"`
+	SurvivorID   uuid.UUID `gorm:"type:uuid;index;not null"`
+	MergedID     uuid.UUID `gorm:"type:uuid;uniqueIndex;not null"`
+	MergedUserID int64     // owner of the merged address
+	Snapshot     string    `gorm:"type:jsonb"` // the merged row as it was
+	MergedBy     int64     // admin who ran the merge
+	CreatedAt    time.Time
+}
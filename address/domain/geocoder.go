@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/imimran/go-grpc-auth/apperr"
+)
+
+// Accuracy levels reported by geocoding providers, most precise first.
+const (
+	AccuracyRooftop     = "rooftop"
+	AccuracyStreet      = "street"
+	AccuracyLocality    = "locality"
+	AccuracyRegion      = "region"
+	AccuracyCountry     = "country"
+	AccuracyApproximate = "approximate"
+)
+
+// Address sources. SourceClient means the caller supplied the coordinates.
+const (
+	SourceClient   = "client"
+	SourceGeocoder = "geocoder"
+)
+
+var (
+	// ErrNoGeocodeResult means no provider could resolve the address.
+	ErrNoGeocodeResult = apperr.InvalidArgument("NO_GEOCODE_RESULT", "raw_address could not be geocoded; send coordinates").
+				WithField("coordinates", "required when raw_address cannot be geocoded")
+	// ErrNoAddressNearPoint is ErrNoGeocodeResult for reverse geocoding.
+	ErrNoAddressNearPoint = apperr.NotFound("NO_ADDRESS_NEAR_POINT", "no address found near point")
+	// ErrGeocoderUnavailable means providers failed (network, bad response)
+	// before any of them could answer.
+	ErrGeocoderUnavailable = apperr.Unavailable("GEOCODER_UNAVAILABLE", "geocoding provider unavailable, retry later or send coordinates")
+	// ErrCoordinatesRequired is returned when an address has no coordinates
+	// and no geocoder is configured to resolve them.
+	ErrCoordinatesRequired = apperr.InvalidArgument("COORDINATES_REQUIRED", "coordinates are required when raw_address cannot be geocoded").
+				WithField("coordinates", "required when raw_address cannot be geocoded")
+)
+
+// GeocodeResult is a provider's best match for a free-form address, or for
+// a point when reverse geocoding.
+type GeocodeResult struct {
+	Address     string // formatted address; always set by Reverse
+	Coordinates Coordinates
+	Accuracy    string
+	Confidence  float64 // 0..1, provider specific
+	Provider    string  // name of the provider that answered
+}
+
+// Geocoder resolves a free-form address to coordinates and back.
+// Implementations return ErrNoGeocodeResult when they have no match.
+type Geocoder interface {
+	Name() string
+	Geocode(ctx context.Context, query string) (*GeocodeResult, error)
+	Reverse(ctx context.Context, point Coordinates) (*GeocodeResult, error)
+}
+
+// ReverseGeocodeResult is the address closest to a point. Stored is true when
+// it is an existing row; otherwise Address is an unsaved geocoder candidate.
+type ReverseGeocodeResult struct {
+	Address        Address
+	DistanceMeters float64
+	Confidence     float64 // 0..1
+	Stored         bool
+}
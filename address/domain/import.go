@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"github.com/imimran/go-grpc-auth/apperr"
+)
+
+// ErrUnreadableImport wraps errors after which the rest of an import file
+// cannot be read (truncated upload, broken JSON).
+var ErrUnreadableImport = apperr.InvalidArgument("UNREADABLE_IMPORT", "import file unreadable")
+
+// ErrInvalidImportRow rejects a single row; its message describes the
+// problem with the row's own content.
+var ErrInvalidImportRow = apperr.InvalidArgument("INVALID_IMPORT_ROW", "invalid row")
+
+// Outcome of a single imported row
+const (
+	ImportCreated   = "created"
+	ImportDuplicate = "duplicate"
+	ImportRejected  = "rejected"
+)
+
+// ImportRow is one record read from an import file. Err is set when the
+// record itself could not be parsed; the row is then rejected.
+type ImportRow struct {
+	Line    int // 1-based record number, header excluded
+	Address Address
+	Err     error
+}
+
+type ImportResult struct {
+	Line      int
+	Status    string
+	AddressID uuid.UUID // set for created rows
+	Reason    string    // why the row was rejected or what it duplicates
+}
+
+type ImportSummary struct {
+	Created    int
+	Duplicates int
+	Rejected   int
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/imimran/go-grpc-auth/apperr"
+)
+
+var ErrUnknownField = apperr.InvalidArgument("UNKNOWN_FIELD", "unknown update_mask path")
+
+// UpdatePaths lists the update_mask paths UpdateAddress accepts. "components"
+// replaces every component, "components.street" only one of them.
+var UpdatePaths = []string{
+	"raw_address",
+	"coordinates",
+	"coordinates.latitude",
+	"coordinates.longitude",
+	"accuracy",
+	"source",
+	"components",
+	"components.house_number",
+	"components.street",
+	"components.unit",
+	"components.locality",
+	"components.region",
+	"components.postal_code",
+	"components.country_code",
+}
+
+// ApplyFields copies the fields named by paths from src onto a and leaves
+// every other field alone. Unknown paths are rejected before anything is
+// copied.
+func (a *Address) ApplyFields(src *Address, paths []string) error {
+	for _, p := range paths {
+		if !isUpdatePath(p) {
+			return ErrUnknownField.WithMessage("unknown update_mask path %q", p).WithField("update_mask", "unknown path "+p)
+		}
+	}
+
+	for _, p := range paths {
+		switch p {
+		case "raw_address":
+			a.RawAddress = src.RawAddress
+		case "coordinates":
+			a.Coordinates = src.Coordinates
+		case "coordinates.latitude":
+			a.Coordinates.Latitude = src.Coordinates.Latitude
+		case "coordinates.longitude":
+			a.Coordinates.Longitude = src.Coordinates.Longitude
+		case "accuracy":
+			a.Accuracy = src.Accuracy
+		case "source":
+			a.Source = src.Source
+		case "components":
+			a.Components = src.Components
+		case "components.house_number":
+			a.Components.HouseNumber = src.Components.HouseNumber
+		case "components.street":
+			a.Components.Street = src.Components.Street
+		case "components.unit":
+			a.Components.Unit = src.Components.Unit
+		case "components.locality":
+			a.Components.Locality = src.Components.Locality
+		case "components.region":
+			a.Components.Region = src.Components.Region
+		case "components.postal_code":
+			a.Components.PostalCode = src.Components.PostalCode
+		case "components.country_code":
+			a.Components.CountryCode = src.Components.CountryCode
+		}
+	}
+	return nil
+}
+
+// HasPath reports whether paths touches field or one of its subfields.
+func HasPath(paths []string, field string) bool {
+	for _, p := range paths {
+		if p == field || strings.HasPrefix(p, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func isUpdatePath(path string) bool {
+	for _, p := range UpdatePaths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+)
+
+// CSVWriter writes a header row followed by one row per address.
+type CSVWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+func (c *CSVWriter) Write(addr *domain.Address) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	row := make([]string, 0, len(fields)+2)
+	for _, f := range fields {
+		row = append(row, f.value(addr))
+	}
+	row = append(row, formatFloat(addr.Coordinates.Latitude), formatFloat(addr.Coordinates.Longitude))
+	return c.w.Write(row)
+}
+
+// Close writes the header if there were no rows, so an empty export is still
+// a valid file, and flushes.
+func (c *CSVWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *CSVWriter) writeHeader() error {
+	if c.wroteHeader {
+		return nil
+	}
+	c.wroteHeader = true
+
+	header := make([]string, 0, len(fields)+2)
+	for _, f := range fields {
+		header = append(header, f.name)
+	}
+	header = append(header, "latitude", "longitude")
+	return c.w.Write(header)
+}
@@ -0,0 +1,107 @@
+// Package exporter writes addresses as CSV, GeoJSON or KML one record at a
+// time, so exports of any size can be streamed without buffering the result.
+// The CSV columns and GeoJSON properties use the importer's names, so an
+// export can be loaded again with the import command.
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+)
+
+// Supported formats
+const (
+	FormatCSV     = "csv"
+	FormatGeoJSON = "geojson"
+	FormatKML     = "kml"
+)
+
+// Writer encodes addresses to an underlying io.Writer. Close writes any
+// trailer the format needs; it does not close the underlying writer.
+type Writer interface {
+	Write(addr *domain.Address) error
+	Close() error
+}
+
+// NewWriter returns a Writer for format over w. An empty format means GeoJSON.
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch normalizeFormat(format) {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatGeoJSON:
+		return NewGeoJSONWriter(w), nil
+	case FormatKML:
+		return NewKMLWriter(w), nil
+	}
+	return nil, fmt.Errorf("unsupported export format %q, expected geojson, csv or kml", format)
+}
+
+// ContentType returns the MIME type of format.
+func ContentType(format string) string {
+	switch normalizeFormat(format) {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatKML:
+		return "application/vnd.google-earth.kml+xml"
+	}
+	return "application/geo+json"
+}
+
+// FileName returns a download file name for format, e.g. "addresses.csv".
+func FileName(format string) string {
+	return "addresses." + normalizeFormat(format)
+}
+
+// FormatFromPath guesses the format from a file extension.
+func FormatFromPath(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".geojson", ".json":
+		return FormatGeoJSON, nil
+	case ".kml":
+		return FormatKML, nil
+	}
+	return "", fmt.Errorf("cannot tell the format of %s, expected .csv, .geojson or .kml", path)
+}
+
+func normalizeFormat(format string) string {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		return FormatGeoJSON
+	}
+	return format
+}
+
+// field is one exported attribute, in column order
+type field struct {
+	name  string
+	value func(a *domain.Address) string
+}
+
+// fields lists the attributes every format writes besides the coordinates
+var fields = []field{
+	{"id", func(a *domain.Address) string { return a.ID.String() }},
+	{"user_id", func(a *domain.Address) string { return strconv.FormatInt(a.UserID, 10) }},
+	{"raw_address", func(a *domain.Address) string { return a.RawAddress }},
+	{"normalized_address", func(a *domain.Address) string { return a.NormalizedAddress }},
+	{"accuracy", func(a *domain.Address) string { return a.Accuracy }},
+	{"source", func(a *domain.Address) string { return a.Source }},
+	{"geocoded_by", func(a *domain.Address) string { return a.GeocodedBy }},
+	{"house_number", func(a *domain.Address) string { return a.Components.HouseNumber }},
+	{"street", func(a *domain.Address) string { return a.Components.Street }},
+	{"unit", func(a *domain.Address) string { return a.Components.Unit }},
+	{"locality", func(a *domain.Address) string { return a.Components.Locality }},
+	{"region", func(a *domain.Address) string { return a.Components.Region }},
+	{"postal_code", func(a *domain.Address) string { return a.Components.PostalCode }},
+	{"country_code", func(a *domain.Address) string { return a.Components.CountryCode }},
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
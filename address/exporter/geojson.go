@@ -0,0 +1,86 @@
+package exporter
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+)
+
+// GeoJSONWriter writes a FeatureCollection with one Point feature per
+// address. The collection is opened on the first write and closed by Close.
+type GeoJSONWriter struct {
+	w        io.Writer
+	started  bool
+	features int
+}
+
+func NewGeoJSONWriter(w io.Writer) *GeoJSONWriter {
+	return &GeoJSONWriter{w: w}
+}
+
+type geoJSONFeature struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id"`
+	Geometry   geoJSONPoint      `json:"geometry"`
+	Properties map[string]string `json:"properties"`
+}
+
+type geoJSONPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+func (g *GeoJSONWriter) Write(addr *domain.Address) error {
+	if err := g.start(); err != nil {
+		return err
+	}
+
+	props := make(map[string]string, len(fields))
+	for _, f := range fields {
+		if v := f.value(addr); v != "" {
+			props[f.name] = v
+		}
+	}
+	body, err := json.Marshal(geoJSONFeature{
+		Type: "Feature",
+		ID:   addr.ID.String(),
+		Geometry: geoJSONPoint{
+			Type: "Point",
+			// GeoJSON positions are [longitude, latitude]
+			Coordinates: [2]float64{addr.Coordinates.Longitude, addr.Coordinates.Latitude},
+		},
+		Properties: props,
+	})
+	if err != nil {
+		return err
+	}
+
+	sep := ",\n"
+	if g.features == 0 {
+		sep = "\n"
+	}
+	g.features++
+	if _, err := io.WriteString(g.w, sep); err != nil {
+		return err
+	}
+	_, err = g.w.Write(body)
+	return err
+}
+
+func (g *GeoJSONWriter) Close() error {
+	if err := g.start(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(g.w, "\n]}\n")
+	return err
+}
+
+func (g *GeoJSONWriter) start() error {
+	if g.started {
+		return nil
+	}
+	g.started = true
+	_, err := io.WriteString(g.w, `{"type":"FeatureCollection","features":[`)
+	return err
+}
@@ -0,0 +1,68 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/xml"
+	"io"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+)
+
+// KMLWriter writes a KML document with one Placemark per address. The
+// remaining attributes are kept as ExtendedData.
+type KMLWriter struct {
+	w       *bufio.Writer
+	started bool
+}
+
+func NewKMLWriter(w io.Writer) *KMLWriter {
+	return &KMLWriter{w: bufio.NewWriter(w)}
+}
+
+func (k *KMLWriter) Write(addr *domain.Address) error {
+	k.start()
+
+	k.w.WriteString("<Placemark id=\"")
+	k.escape(addr.ID.String())
+	k.w.WriteString("\">\n<name>")
+	k.escape(addr.RawAddress)
+	k.w.WriteString("</name>\n<ExtendedData>\n")
+	for _, f := range fields {
+		v := f.value(addr)
+		if v == "" {
+			continue
+		}
+		k.w.WriteString("<Data name=\"" + f.name + "\"><value>")
+		k.escape(v)
+		k.w.WriteString("</value></Data>\n")
+	}
+	// KML coordinates are longitude,latitude
+	k.w.WriteString("</ExtendedData>\n<Point><coordinates>")
+	k.w.WriteString(formatFloat(addr.Coordinates.Longitude) + "," + formatFloat(addr.Coordinates.Latitude))
+	k.w.WriteString("</coordinates></Point>\n</Placemark>\n")
+
+	// bufio keeps the first error and returns it from every later call
+	_, err := k.w.WriteString("")
+	return err
+}
+
+func (k *KMLWriter) Close() error {
+	k.start()
+	k.w.WriteString("</Document>\n</kml>\n")
+	return k.w.Flush()
+}
+
+func (k *KMLWriter) start() {
+	if k.started {
+		return
+	}
+	k.started = true
+	k.w.WriteString(xml.Header)
+	k.w.WriteString("<kml xmlns=\"http://www.opengis.net/kml/2.2\">\n<Document>\n<name>Addresses</name>\n")
+}
+
+func (k *KMLWriter) escape(s string) {
+	// EscapeText only fails when the underlying writer does, which bufio
+	// remembers
+	_ = xml.EscapeText(k.w, []byte(s))
+}
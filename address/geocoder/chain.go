@@ -0,0 +1,62 @@
+package geocoder
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+)
+
+// Chain asks each provider in order and returns the first match. A provider
+// that fails is logged and skipped so an outage of one does not block the rest.
+type Chain struct {
+	providers []domain.Geocoder
+}
+
+func NewChain(providers ...domain.Geocoder) *Chain {
+	return &Chain{providers: providers}
+}
+
+func (c *Chain) Name() string {
+	return "chain"
+}
+
+func (c *Chain) Geocode(ctx context.Context, query string) (*domain.GeocodeResult, error) {
+	return c.first(ctx, func(p domain.Geocoder) (*domain.GeocodeResult, error) {
+		return p.Geocode(ctx, query)
+	})
+}
+
+func (c *Chain) Reverse(ctx context.Context, point domain.Coordinates) (*domain.GeocodeResult, error) {
+	return c.first(ctx, func(p domain.Geocoder) (*domain.GeocodeResult, error) {
+		return p.Reverse(ctx, point)
+	})
+}
+
+func (c *Chain) first(ctx context.Context, call func(domain.Geocoder) (*domain.GeocodeResult, error)) (*domain.GeocodeResult, error) {
+	var lastErr error
+	for _, p := range c.providers {
+		result, err := call(p)
+		if err == nil {
+			if result.Provider == "" {
+				result.Provider = p.Name()
+			}
+			return result, nil
+		}
+		if errors.Is(err, domain.ErrNoGeocodeResult) {
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		log.Printf("geocoder %s failed: %v", p.Name(), err)
+		lastErr = err
+	}
+
+	// Only report an outage when nobody could give a definite answer
+	if lastErr != nil {
+		return nil, domain.ErrGeocoderUnavailable.Wrap(lastErr)
+	}
+	return nil, domain.ErrNoGeocodeResult
+}
@@ -0,0 +1,46 @@
+package geocoder
+
+import (
+	"fmt"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+	"github.com/imimran/go-grpc-auth/config"
+)
+
+// New builds the provider chain described by cfg. It returns nil when no
+// providers are configured.
+func New(cfg config.GeocodingConfig) (domain.Geocoder, error) {
+	if len(cfg.Providers) == 0 {
+		return nil, nil
+	}
+
+	providers := make([]domain.Geocoder, 0, len(cfg.Providers))
+	for i, p := range cfg.Providers {
+		name := p.Name
+		if name == "" {
+			name = p.Type
+		}
+
+		var provider domain.Geocoder
+		var err error
+		switch p.Type {
+		case "nominatim":
+			provider, err = NewNominatim(NominatimOptions{
+				Name:      name,
+				BaseURL:   p.URL,
+				UserAgent: p.UserAgent,
+				Email:     p.Email,
+				Timeout:   p.Timeout,
+			})
+		case "gazetteer":
+			provider, err = LoadGazetteer(name, p.File)
+		default:
+			err = fmt.Errorf("unknown provider type %q", p.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("geocoding provider %d: %w", i, err)
+		}
+		providers = append(providers, provider)
+	}
+	return NewChain(providers...), nil
+}
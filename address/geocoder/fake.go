@@ -0,0 +1,55 @@
+package geocoder
+
+import (
+	"context"
+	"strings"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+)
+
+// Fake answers from a fixed table, keyed by the lowercased, trimmed query.
+// Reverse returns the entry nearest to the point. Err, when set, is returned
+// for every call.
+type Fake struct {
+	Results map[string]domain.GeocodeResult
+	Err     error
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) Geocode(ctx context.Context, query string) (*domain.GeocodeResult, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	result, ok := f.Results[strings.ToLower(strings.TrimSpace(query))]
+	if !ok {
+		return nil, domain.ErrNoGeocodeResult
+	}
+	result.Provider = f.Name()
+	return &result, nil
+}
+
+func (f *Fake) Reverse(ctx context.Context, point domain.Coordinates) (*domain.GeocodeResult, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	var best *domain.GeocodeResult
+	bestDistance := 0.0
+	for query, result := range f.Results {
+		d := point.DistanceTo(result.Coordinates)
+		if best == nil || d < bestDistance {
+			r := result
+			if r.Address == "" {
+				r.Address = query
+			}
+			best, bestDistance = &r, d
+		}
+	}
+	if best == nil {
+		return nil, domain.ErrNoGeocodeResult
+	}
+	best.Provider = f.Name()
+	return best, nil
+}
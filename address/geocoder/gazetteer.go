@@ -0,0 +1,134 @@
+package geocoder
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+)
+
+// Gazetteer answers from a local CSV file loaded into memory, so lookups
+// work without network access. Each row is
+//
+//	address,latitude,longitude[,accuracy]
+//
+// Lines starting with # are comments. Accuracy defaults to rooftop.
+type Gazetteer struct {
+	name    string
+	entries map[string]domain.GeocodeResult
+	// MaxReverseDistanceMeters bounds Reverse lookups; beyond it the nearest
+	// entry is not considered a match.
+	MaxReverseDistanceMeters float64
+}
+
+const defaultGazetteerReverseDistance = 1000
+
+func LoadGazetteer(name, path string) (*Gazetteer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("gazetteer: %w", err)
+	}
+	defer f.Close()
+
+	g, err := ReadGazetteer(name, f)
+	if err != nil {
+		return nil, fmt.Errorf("gazetteer %s: %w", path, err)
+	}
+	return g, nil
+}
+
+func ReadGazetteer(name string, r io.Reader) (*Gazetteer, error) {
+	if name == "" {
+		name = "gazetteer"
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	g := &Gazetteer{
+		name:                     name,
+		entries:                  map[string]domain.GeocodeResult{},
+		MaxReverseDistanceMeters: defaultGazetteerReverseDistance,
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) < 3 {
+			return nil, fmt.Errorf("line %d: expected address,latitude,longitude", line)
+		}
+
+		lat, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid latitude %q", line, record[1])
+		}
+		lon, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid longitude %q", line, record[2])
+		}
+		coords := domain.Coordinates{Latitude: lat, Longitude: lon}
+		if !coords.Valid() {
+			return nil, fmt.Errorf("line %d: coordinates out of range", line)
+		}
+
+		accuracy := domain.AccuracyRooftop
+		if len(record) > 3 && record[3] != "" {
+			accuracy = record[3]
+		}
+
+		g.entries[gazetteerKey(record[0])] = domain.GeocodeResult{
+			Address:     strings.TrimSpace(record[0]),
+			Coordinates: coords,
+			Accuracy:    accuracy,
+			Confidence:  1,
+			Provider:    name,
+		}
+	}
+	return g, nil
+}
+
+func (g *Gazetteer) Name() string {
+	return g.name
+}
+
+func (g *Gazetteer) Geocode(ctx context.Context, query string) (*domain.GeocodeResult, error) {
+	result, ok := g.entries[gazetteerKey(query)]
+	if !ok {
+		return nil, domain.ErrNoGeocodeResult
+	}
+	return &result, nil
+}
+
+// Reverse returns the nearest entry. The scan is linear, which is fine for
+// the few thousand entries a local gazetteer typically holds.
+func (g *Gazetteer) Reverse(ctx context.Context, point domain.Coordinates) (*domain.GeocodeResult, error) {
+	var best *domain.GeocodeResult
+	bestDistance := g.MaxReverseDistanceMeters
+	for _, entry := range g.entries {
+		if d := point.DistanceTo(entry.Coordinates); d <= bestDistance {
+			e := entry
+			best, bestDistance = &e, d
+		}
+	}
+	if best == nil {
+		return nil, domain.ErrNoGeocodeResult
+	}
+	return best, nil
+}
+
+// gazetteerKey makes lookups insensitive to case and spacing
+func gazetteerKey(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
@@ -0,0 +1,170 @@
+package geocoder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+)
+
+// Nominatim queries the /search and /reverse endpoints of a Nominatim-compatible API (OpenStreetMap,
+// a self-hosted instance, or a commercial clone such as LocationIQ).
+type Nominatim struct {
+	name      string
+	baseURL   string
+	userAgent string
+	email     string
+	client    *http.Client
+}
+
+type NominatimOptions struct {
+	Name      string        // defaults to "nominatim"
+	BaseURL   string        // e.g. https://nominatim.openstreetmap.org
+	UserAgent string        // required by the public instance's usage policy
+	Email     string        // optional contact address sent with each request
+	Timeout   time.Duration // defaults to 5s
+}
+
+func NewNominatim(opts NominatimOptions) (*Nominatim, error) {
+	if opts.BaseURL == "" {
+		return nil, fmt.Errorf("nominatim: url is required")
+	}
+	if _, err := url.Parse(opts.BaseURL); err != nil {
+		return nil, fmt.Errorf("nominatim: invalid url: %w", err)
+	}
+	if opts.Name == "" {
+		opts.Name = "nominatim"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	return &Nominatim{
+		name:      opts.Name,
+		baseURL:   strings.TrimRight(opts.BaseURL, "/"),
+		userAgent: opts.UserAgent,
+		email:     opts.Email,
+		client:    &http.Client{Timeout: opts.Timeout},
+	}, nil
+}
+
+func (n *Nominatim) Name() string {
+	return n.name
+}
+
+// nominatimPlace is the subset of a jsonv2 search result we use
+type nominatimPlace struct {
+	Lat         string  `json:"lat"`
+	Lon         string  `json:"lon"`
+	DisplayName string  `json:"display_name"`
+	PlaceRank   int     `json:"place_rank"`
+	Importance  float64 `json:"importance"`
+	Error       string  `json:"error"` // set by /reverse when nothing is found
+}
+
+func (n *Nominatim) Geocode(ctx context.Context, query string) (*domain.GeocodeResult, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("format", "jsonv2")
+	params.Set("limit", "1")
+	if n.email != "" {
+		params.Set("email", n.email)
+	}
+
+	var places []nominatimPlace
+	if err := n.get(ctx, "/search", params, &places); err != nil {
+		return nil, err
+	}
+	if len(places) == 0 {
+		return nil, domain.ErrNoGeocodeResult
+	}
+	return n.toResult(places[0])
+}
+
+func (n *Nominatim) Reverse(ctx context.Context, point domain.Coordinates) (*domain.GeocodeResult, error) {
+	params := url.Values{}
+	params.Set("lat", strconv.FormatFloat(point.Latitude, 'f', -1, 64))
+	params.Set("lon", strconv.FormatFloat(point.Longitude, 'f', -1, 64))
+	params.Set("format", "jsonv2")
+	if n.email != "" {
+		params.Set("email", n.email)
+	}
+
+	var place nominatimPlace
+	if err := n.get(ctx, "/reverse", params, &place); err != nil {
+		return nil, err
+	}
+	if place.Error != "" || place.Lat == "" {
+		return nil, domain.ErrNoGeocodeResult
+	}
+	return n.toResult(place)
+}
+
+func (n *Nominatim) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, n.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if n.userAgent != "" {
+		req.Header.Set("User-Agent", n.userAgent)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", n.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status %s", n.name, resp.Status)
+	}
+	// Results are small; cap the body so a misbehaving server can't exhaust memory
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(out); err != nil {
+		return fmt.Errorf("%s: decode response: %w", n.name, err)
+	}
+	return nil
+}
+
+func (n *Nominatim) toResult(p nominatimPlace) (*domain.GeocodeResult, error) {
+	lat, err := strconv.ParseFloat(p.Lat, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid lat %q", n.name, p.Lat)
+	}
+	lon, err := strconv.ParseFloat(p.Lon, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid lon %q", n.name, p.Lon)
+	}
+	return &domain.GeocodeResult{
+		Address:     p.DisplayName,
+		Coordinates: domain.Coordinates{Latitude: lat, Longitude: lon},
+		Accuracy:    accuracyForPlaceRank(p.PlaceRank),
+		Confidence:  p.Importance,
+		Provider:    n.name,
+	}, nil
+}
+
+// accuracyForPlaceRank maps Nominatim's place_rank (4 = country .. 30 = building)
+// onto our accuracy levels.
+func accuracyForPlaceRank(rank int) string {
+	switch {
+	case rank >= 28:
+		return domain.AccuracyRooftop
+	case rank >= 26:
+		return domain.AccuracyStreet
+	case rank >= 13:
+		return domain.AccuracyLocality
+	case rank >= 5:
+		return domain.AccuracyRegion
+	case rank >= 4:
+		return domain.AccuracyCountry
+	default:
+		return domain.AccuracyApproximate
+	}
+}
@@ -0,0 +1,80 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+)
+
+// CSVReader reads a CSV file with a header row. raw_address (or address)
+// is required; latitude, longitude, accuracy, source, user_id and the
+// component columns (house_number, street, unit, locality, region,
+// postal_code, country_code) are optional.
+type CSVReader struct {
+	r      *csv.Reader
+	header []string
+	line   int
+}
+
+func NewCSVReader(r io.Reader) (*CSVReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("csv: file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("csv: read header: %w", err)
+	}
+	header = append([]string(nil), header...)
+
+	hasAddress := false
+	for i, h := range header {
+		// Spreadsheet exports often start with a byte order mark
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		header[i] = h
+		if h == "raw_address" || h == "address" {
+			hasAddress = true
+		}
+	}
+	if !hasAddress {
+		return nil, errors.New("csv: header must include a raw_address column")
+	}
+	return &CSVReader{r: reader, header: header}, nil
+}
+
+func (c *CSVReader) Next() (domain.ImportRow, error) {
+	record, err := c.r.Read()
+	if err == io.EOF {
+		return domain.ImportRow{}, io.EOF
+	}
+	c.line++
+	row := domain.ImportRow{Line: c.line}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		// The reader resynchronises on the next line, so only this row is lost
+		row.Err = parseErr.Err
+		return row, nil
+	}
+	if err != nil {
+		return row, err
+	}
+
+	for i, value := range record {
+		if i >= len(c.header) {
+			break
+		}
+		if err := setField(&row.Address, c.header[i], value); err != nil {
+			row.Err = err
+			break
+		}
+	}
+	return row, nil
+}
@@ -0,0 +1,133 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+)
+
+// GeoJSONReader streams the features of a FeatureCollection without loading
+// the whole document. Each feature needs an address or raw_address property;
+// a Point geometry supplies the coordinates, other properties map like CSV
+// columns.
+type GeoJSONReader struct {
+	dec  *json.Decoder
+	line int
+	done bool
+}
+
+type geoJSONFeature struct {
+	Type     string `json:"type"`
+	Geometry *struct {
+		Type        string    `json:"type"`
+		Coordinates []float64 `json:"coordinates"`
+	} `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+func NewGeoJSONReader(r io.Reader) (*GeoJSONReader, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	// Skip members until "features"; "type" is checked on the way
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("geojson: %w", err)
+		}
+		switch key {
+		case "features":
+			if err := expectDelim(dec, '['); err != nil {
+				return nil, err
+			}
+			return &GeoJSONReader{dec: dec}, nil
+		case "type":
+			var typ string
+			if err := dec.Decode(&typ); err != nil {
+				return nil, fmt.Errorf("geojson: %w", err)
+			}
+			if typ != "FeatureCollection" {
+				return nil, fmt.Errorf("geojson: expected a FeatureCollection, got %q", typ)
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, fmt.Errorf("geojson: %w", err)
+			}
+		}
+	}
+	return nil, errors.New("geojson: FeatureCollection has no features")
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("geojson: %w", err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("geojson: expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
+func (g *GeoJSONReader) Next() (domain.ImportRow, error) {
+	if g.done || !g.dec.More() {
+		g.done = true
+		return domain.ImportRow{}, io.EOF
+	}
+
+	var feature geoJSONFeature
+	if err := g.dec.Decode(&feature); err != nil {
+		// A syntax error leaves the decoder unusable
+		g.done = true
+		return domain.ImportRow{}, fmt.Errorf("geojson: feature %d: %w", g.line+1, err)
+	}
+	g.line++
+	row := domain.ImportRow{Line: g.line}
+	row.Err = featureToAddress(&feature, &row.Address)
+	return row, nil
+}
+
+func featureToAddress(f *geoJSONFeature, addr *domain.Address) error {
+	if f.Type != "Feature" {
+		return fmt.Errorf("expected a Feature, got %q", f.Type)
+	}
+
+	for name, value := range f.Properties {
+		var s string
+		switch v := value.(type) {
+		case string:
+			s = v
+		case json.Number:
+			s = v.String()
+		case bool:
+			s = strconv.FormatBool(v)
+		case nil:
+			continue
+		default:
+			// nested objects are not address fields
+			continue
+		}
+		if err := setField(addr, name, s); err != nil {
+			return err
+		}
+	}
+
+	if f.Geometry != nil {
+		if f.Geometry.Type != "Point" || len(f.Geometry.Coordinates) < 2 {
+			return fmt.Errorf("geometry must be a Point, got %q", f.Geometry.Type)
+		}
+		addr.Coordinates = domain.Coordinates{
+			Longitude: f.Geometry.Coordinates[0],
+			Latitude:  f.Geometry.Coordinates[1],
+		}
+	}
+	return nil
+}
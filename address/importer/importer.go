@@ -0,0 +1,109 @@
+// Package importer reads addresses from CSV files and GeoJSON
+// FeatureCollections one record at a time, so arbitrarily large files can be
+// streamed into the database.
+package importer
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+)
+
+// Supported formats
+const (
+	FormatCSV     = "csv"
+	FormatGeoJSON = "geojson"
+)
+
+// Reader yields rows until it returns io.EOF. Any other error means the
+// input is unreadable from that point on.
+type Reader interface {
+	Next() (domain.ImportRow, error)
+}
+
+// NewReader returns a Reader for format over r.
+func NewReader(format string, r io.Reader) (Reader, error) {
+	var reader Reader
+	var err error
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		reader, err = NewCSVReader(r)
+	case FormatGeoJSON:
+		reader, err = NewGeoJSONReader(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q, expected csv or geojson", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// FormatFromPath guesses the format from a file extension.
+func FormatFromPath(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".geojson", ".json":
+		return FormatGeoJSON, nil
+	}
+	return "", fmt.Errorf("cannot tell the format of %s, expected .csv or .geojson", path)
+}
+
+// setField assigns a CSV column or GeoJSON property to addr. Unknown names
+// are ignored so exports from other tools can be loaded as they are.
+func setField(addr *domain.Address, name, value string) error {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "raw_address", "address":
+		addr.RawAddress = value
+	case "accuracy":
+		addr.Accuracy = value
+	case "source":
+		addr.Source = value
+	case "house_number":
+		addr.Components.HouseNumber = value
+	case "street":
+		addr.Components.Street = value
+	case "unit":
+		addr.Components.Unit = value
+	case "locality":
+		addr.Components.Locality = value
+	case "region":
+		addr.Components.Region = value
+	case "postal_code":
+		addr.Components.PostalCode = value
+	case "country_code":
+		addr.Components.CountryCode = value
+	case "latitude", "lat":
+		return parseFloat(&addr.Coordinates.Latitude, "latitude", value)
+	case "longitude", "lon", "lng":
+		return parseFloat(&addr.Coordinates.Longitude, "longitude", value)
+	case "user_id":
+		if value == "" {
+			return nil
+		}
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid user_id %q", value)
+		}
+		addr.UserID = id
+	}
+	return nil
+}
+
+func parseFloat(dst *float64, name, value string) error {
+	if value == "" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", name, value)
+	}
+	*dst = f
+	return nil
+}
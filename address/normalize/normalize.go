@@ -0,0 +1,196 @@
+// Package normalize turns free-form addresses into a canonical form so that
+// "12 Main St." and "12 main street" compare equal.
+package normalize
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Version identifies the current rule set. Bump it whenever the pipeline or
+// any locale table changes so stored rows can be found and re-normalized.
+// Rows written before versioning have version 0.
+const Version = 1
+
+// DefaultLocale is used when no locale is configured.
+const DefaultLocale = "en"
+
+// Normalizer applies one locale's rules. It is safe for concurrent use.
+type Normalizer struct {
+	rules *Rules
+}
+
+// New returns a normalizer for locale, e.g. "en" or "de".
+func New(locale string) (*Normalizer, error) {
+	if locale == "" {
+		locale = DefaultLocale
+	}
+	rules, ok := locales[strings.ToLower(locale)]
+	if !ok {
+		return nil, fmt.Errorf("normalize: unsupported locale %q (supported: %s)", locale, strings.Join(Locales(), ", "))
+	}
+	return &Normalizer{rules: rules}, nil
+}
+
+// Locales lists the supported locale codes.
+func Locales() []string {
+	out := make([]string, 0, len(locales))
+	for code := range locales {
+		out = append(out, code)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (n *Normalizer) Locale() string {
+	return n.rules.Locale
+}
+
+func (n *Normalizer) Version() int {
+	return Version
+}
+
+// Normalize runs the pipeline:
+//  1. unicode: compatibility-decompose, drop accents, case-fold
+//  2. punctuation: everything but letters and digits separates tokens,
+//     except hyphens and slashes inside house numbers ("12-14", "12 1/2")
+//  3. house numbers: "No. 12" -> "12", "012" -> "12", "12-B" -> "12b"
+//  4. abbreviations: locale table, e.g. "st" -> "street", "apt" -> "apartment"
+//  5. whitespace: tokens joined by a single space
+func (n *Normalizer) Normalize(raw string) string {
+	tokens := tokenize(foldUnicode(raw))
+	tokens = normalizeHouseNumbers(tokens, n.rules.NumberPrefixes)
+	tokens = n.expand(tokens)
+	return strings.Join(tokens, " ")
+}
+
+// foldUnicode maps "Ｓｔｒａßｅ" and "Straße" alike to "strasse", and "Émile" to "emile"
+func foldUnicode(s string) string {
+	t := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	out, _, err := transform.String(t, s)
+	if err != nil {
+		out = s
+	}
+	return cases.Fold().String(out)
+}
+
+func tokenize(s string) []string {
+	r := []rune(s)
+	var tokens []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			tokens = append(tokens, string(cur))
+			cur = cur[:0]
+		}
+	}
+
+	for i, c := range r {
+		switch {
+		case unicode.IsLetter(c) || unicode.IsDigit(c):
+			cur = append(cur, c)
+		case (c == '\'' || c == '’') && len(cur) > 0 && i+1 < len(r) && unicode.IsLetter(r[i+1]):
+			// "Mary's" -> "marys", not "mary s"
+		case (c == '-' || c == '/') && len(cur) > 0 && isDigit(cur[len(cur)-1]) &&
+			i+1 < len(r) && (unicode.IsLetter(r[i+1]) || unicode.IsDigit(r[i+1])):
+			// keep "12-14", "12/3" and "12-b" together; the suffix case is
+			// resolved by normalizeHouseNumbers
+			cur = append(cur, c)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+func normalizeHouseNumbers(tokens []string, prefixes map[string]bool) []string {
+	out := make([]string, 0, len(tokens))
+	for i, tok := range tokens {
+		// "no 12" / "nr 12" -> "12"
+		if prefixes[tok] && i+1 < len(tokens) && startsWithDigit(tokens[i+1]) {
+			continue
+		}
+		if startsWithDigit(tok) {
+			tok = normalizeNumber(tok)
+		}
+		out = append(out, tok)
+	}
+	return out
+}
+
+// normalizeNumber strips leading zeros from each numeric part and folds a
+// single-letter suffix onto the number: "012" -> "12", "12-B" -> "12b".
+func normalizeNumber(tok string) string {
+	var b strings.Builder
+	parts := strings.FieldsFunc(tok, func(r rune) bool { return r == '-' || r == '/' })
+	seps := strings.FieldsFunc(tok, func(r rune) bool { return r != '-' && r != '/' })
+
+	for i, part := range parts {
+		if i > 0 {
+			sep := seps[i-1]
+			// a lone letter after a separator is a suffix, not a range
+			if !(len(part) == 1 && unicode.IsLetter(rune(part[0]))) {
+				b.WriteString(sep)
+			}
+		}
+		trimmed := strings.TrimLeft(part, "0")
+		if trimmed == "" || !startsWithDigit(trimmed) && startsWithDigit(part) {
+			// "0" or "0a" keep their zero
+			trimmed = "0" + trimmed
+		}
+		b.WriteString(trimmed)
+	}
+	return b.String()
+}
+
+func (n *Normalizer) expand(tokens []string) []string {
+	out := make([]string, 0, len(tokens))
+	for i, tok := range tokens {
+		prev, next := "", ""
+		if i > 0 {
+			prev = tokens[i-1]
+		}
+		if i+1 < len(tokens) {
+			next = tokens[i+1]
+		}
+
+		if rule, ok := n.rules.Contextual[tok]; ok {
+			if expansion := rule(prev, next); expansion != "" {
+				out = append(out, expansion)
+				continue
+			}
+		}
+		if expansion, ok := n.rules.Abbreviations[tok]; ok {
+			tok = expansion
+		} else {
+			tok = n.expandSuffix(tok)
+		}
+		out = append(out, tok)
+	}
+	return out
+}
+
+func (n *Normalizer) expandSuffix(tok string) string {
+	for suffix, expansion := range n.rules.Suffixes {
+		if len(tok) > len(suffix) && strings.HasSuffix(tok, suffix) {
+			return strings.TrimSuffix(tok, suffix) + expansion
+		}
+	}
+	return tok
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func startsWithDigit(s string) bool {
+	return s != "" && isDigit(rune(s[0]))
+}
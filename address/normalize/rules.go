@@ -0,0 +1,118 @@
+package normalize
+
+// Rules is one locale's table. Tokens are matched after unicode folding, so
+// keys must be lowercase and accent-free.
+type Rules struct {
+	Locale string
+	// Abbreviations maps a token to its expansion
+	Abbreviations map[string]string
+	// Contextual rules run before Abbreviations for ambiguous tokens. They
+	// return the expansion, or "" to fall through to the table.
+	Contextual map[string]func(prev, next string) string
+	// Suffixes expands abbreviations glued to the end of a word, as in
+	// German "Hauptstr" -> "hauptstrasse"
+	Suffixes map[string]string
+	// NumberPrefixes are dropped when directly followed by a house number
+	NumberPrefixes map[string]bool
+}
+
+var locales = map[string]*Rules{
+	"en": english,
+	"de": german,
+	"fr": french,
+}
+
+var english = &Rules{
+	Locale: "en",
+	Abbreviations: map[string]string{
+		// street types
+		"st":   "street",
+		"str":  "street",
+		"rd":   "road",
+		"ave":  "avenue",
+		"av":   "avenue",
+		"blvd": "boulevard",
+		"dr":   "drive",
+		"ln":   "lane",
+		"ct":   "court",
+		"pl":   "place",
+		"sq":   "square",
+		"ter":  "terrace",
+		"pkwy": "parkway",
+		"hwy":  "highway",
+		"cres": "crescent",
+		"cir":  "circle",
+		"trl":  "trail",
+		"mt":   "mount",
+		"ft":   "fort",
+		// units
+		"apt":  "apartment",
+		"ste":  "suite",
+		"fl":   "floor",
+		"flr":  "floor",
+		"bldg": "building",
+		"rm":   "room",
+		"dept": "department",
+		// directionals
+		"n":  "north",
+		"s":  "south",
+		"e":  "east",
+		"w":  "west",
+		"ne": "northeast",
+		"nw": "northwest",
+		"se": "southeast",
+		"sw": "southwest",
+		// other
+		"po": "post office",
+	},
+	Contextual: map[string]func(prev, next string) string{
+		// "St Mary St": the first is Saint, the second is Street
+		"st": func(prev, next string) string {
+			if next != "" && !startsWithDigit(next) && (prev == "" || startsWithDigit(prev)) {
+				return "saint"
+			}
+			return ""
+		},
+	},
+	NumberPrefixes: map[string]bool{"no": true, "number": true, "num": true, "nr": true},
+}
+
+var german = &Rules{
+	Locale: "de",
+	Abbreviations: map[string]string{
+		"str": "strasse",
+		"pl":  "platz",
+		"hbf": "hauptbahnhof",
+		"og":  "obergeschoss",
+		"eg":  "erdgeschoss",
+		"whg": "wohnung",
+		"st":  "sankt",
+		"geb": "gebaude",
+	},
+	Suffixes:       map[string]string{"str": "strasse"},
+	NumberPrefixes: map[string]bool{"nr": true, "hausnummer": true},
+}
+
+var french = &Rules{
+	Locale: "fr",
+	Abbreviations: map[string]string{
+		"av":   "avenue",
+		"ave":  "avenue",
+		"bd":   "boulevard",
+		"bld":  "boulevard",
+		"bvd":  "boulevard",
+		"r":    "rue",
+		"pl":   "place",
+		"imp":  "impasse",
+		"ch":   "chemin",
+		"rte":  "route",
+		"fg":   "faubourg",
+		"qu":   "quai",
+		"apt":  "appartement",
+		"appt": "appartement",
+		"bat":  "batiment",
+		"st":   "saint",
+		"ste":  "sainte",
+	},
+	NumberPrefixes: map[string]bool{"no": true, "numero": true},
+}
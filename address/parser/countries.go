@@ -0,0 +1,89 @@
+package parser
+
+import "strings"
+
+// countries maps common country names and codes, lowercased, to ISO 3166-1
+// alpha-2. Bare ISO codes are handled by isoCountries.
+var countries = map[string]string{
+	"usa":                      "US",
+	"united states":            "US",
+	"united states of america": "US",
+	"uk":                       "GB",
+	"gb":                       "GB",
+	"united kingdom":           "GB",
+	"great britain":            "GB",
+	"england":                  "GB",
+	"scotland":                 "GB",
+	"wales":                    "GB",
+	"canada":                   "CA",
+	"germany":                  "DE",
+	"deutschland":              "DE",
+	"france":                   "FR",
+	"spain":                    "ES",
+	"espana":                   "ES",
+	"españa":                   "ES",
+	"italy":                    "IT",
+	"italia":                   "IT",
+	"netherlands":              "NL",
+	"nederland":                "NL",
+	"belgium":                  "BE",
+	"austria":                  "AT",
+	"österreich":               "AT",
+	"switzerland":              "CH",
+	"schweiz":                  "CH",
+	"ireland":                  "IE",
+	"australia":                "AU",
+	"new zealand":              "NZ",
+	"india":                    "IN",
+	"bangladesh":               "BD",
+	"pakistan":                 "PK",
+	"singapore":                "SG",
+	"japan":                    "JP",
+	"united arab emirates":     "AE",
+	"uae":                      "AE",
+}
+
+// isoCountries holds every ISO 3166-1 alpha-2 code
+var isoCountries = func() map[string]bool {
+	codes := map[string]bool{}
+	for _, code := range strings.Fields(`
+		AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL
+		BM BN BO BQ BR BS BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV
+		CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR GA GB GD
+		GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM
+		IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK
+		LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW
+		MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR
+		PS PT PW PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS
+		ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ UA UG UM US UY
+		UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW`) {
+		codes[code] = true
+	}
+	return codes
+}()
+
+func countryCode(part string) (string, bool) {
+	code, ok := countries[strings.ToLower(strings.TrimSpace(part))]
+	return code, ok
+}
+
+// isoCountryCode reports whether part is a bare ISO alpha-2 code
+func isoCountryCode(part string) (string, bool) {
+	code := strings.ToUpper(strings.TrimSpace(part))
+	return code, len(code) == 2 && isoCountries[code]
+}
+
+var usStates = map[string]bool{
+	"AL": true, "AK": true, "AZ": true, "AR": true, "CA": true, "CO": true, "CT": true,
+	"DE": true, "DC": true, "FL": true, "GA": true, "HI": true, "ID": true, "IL": true,
+	"IN": true, "IA": true, "KS": true, "KY": true, "LA": true, "ME": true, "MD": true,
+	"MA": true, "MI": true, "MN": true, "MS": true, "MO": true, "MT": true, "NE": true,
+	"NV": true, "NH": true, "NJ": true, "NM": true, "NY": true, "NC": true, "ND": true,
+	"OH": true, "OK": true, "OR": true, "PA": true, "RI": true, "SC": true, "SD": true,
+	"TN": true, "TX": true, "UT": true, "VT": true, "VA": true, "WA": true, "WV": true,
+	"WI": true, "WY": true, "PR": true,
+}
+
+func isUSState(s string) bool {
+	return len(s) == 2 && usStates[strings.ToUpper(s)]
+}
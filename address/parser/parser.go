@@ -0,0 +1,179 @@
+// Package parser splits a free-form, comma separated address into structured
+// components. It is a best-effort heuristic for common layouts such as
+//
+//	12 Main St Apt 4, Springfield, IL 62704, USA
+//	1 Rue Sherbrooke, Montreal, QC H2X 1Y4, CA
+//	Flat 2, 10 Downing Street, London, SW1A 2AA, United Kingdom
+//	Hauptstraße 5, 10115 Berlin, Deutschland
+//	House 12, Road 5, Dhanmondi, Dhaka 1205, Bangladesh
+//
+// and leaves a component empty rather than guessing when a part is ambiguous.
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/imimran/go-grpc-auth/address/domain"
+)
+
+var (
+	// Checked in order; the first match wins
+	postalPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b[A-Z]{1,2}[0-9][A-Z0-9]? ?[0-9][A-Z]{2}\b`), // UK
+		regexp.MustCompile(`(?i)\b[A-Z][0-9][A-Z] ?[0-9][A-Z][0-9]\b`),        // Canada
+		regexp.MustCompile(`\b[0-9]{5}-[0-9]{4}\b`),                           // US ZIP+4
+		regexp.MustCompile(`\b[0-9]{4,6}\b`),                                  // US, DE, FR, BD, ...
+	}
+
+	leadingNumber  = regexp.MustCompile(`^([0-9]+[A-Za-z]?(?:[-/][0-9]+[A-Za-z]?)?)\s+(.+)$`)
+	trailingNumber = regexp.MustCompile(`^(.+?)\s+([0-9]+[A-Za-z]?(?:[-/][0-9]+[A-Za-z]?)?)$`)
+	unitPattern    = regexp.MustCompile(`(?i)(?:^|\s)((?:apt|apartment|unit|suite|ste|flat|room|rm|#)\.?\s*#?\s*[0-9A-Za-z-]+)$`)
+	houseOnly      = regexp.MustCompile(`(?i)^(?:house|building|bldg|plot|holding)\s*(?:no\.?|#)?\s*([0-9]+[A-Za-z]?(?:[-/][0-9]+[A-Za-z]?)?)$`)
+	unitOnly       = regexp.MustCompile(`(?i)^(?:apt|apartment|unit|suite|ste|flat|room|rm|floor|fl|#)\.?\s*#?\s*[0-9A-Za-z-]+$`)
+)
+
+// Parse extracts components from raw. Only fields it is reasonably sure
+// about are set.
+func Parse(raw string) domain.Components {
+	var c domain.Components
+
+	var parts []string
+	for _, p := range strings.Split(raw, ",") {
+		if p = strings.Join(strings.Fields(p), " "); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 0 {
+		return c
+	}
+
+	// 1. Country: a trailing part that names one or is its ISO code
+	if len(parts) > 1 {
+		if code, ok := trailingCountry(parts); ok {
+			c.CountryCode = code
+			parts = parts[:len(parts)-1]
+		}
+	}
+
+	// 2. Postal code: the last non-street part containing one
+	for i := len(parts) - 1; i >= 1 && c.PostalCode == ""; i-- {
+		for _, re := range postalPatterns {
+			if loc := re.FindStringIndex(parts[i]); loc != nil {
+				c.PostalCode = strings.ToUpper(parts[i][loc[0]:loc[1]])
+				rest := strings.TrimSpace(parts[i][:loc[0]] + " " + parts[i][loc[1]:])
+				if rest == "" {
+					parts = append(parts[:i], parts[i+1:]...)
+				} else {
+					parts[i] = strings.Join(strings.Fields(rest), " ")
+				}
+				break
+			}
+		}
+	}
+
+	// 3. Unit or house given as their own part ("Flat 2, 10 Downing Street",
+	// "House 12, Road 5")
+	var rest []string
+	for _, p := range parts {
+		if c.Unit == "" && unitOnly.MatchString(p) {
+			c.Unit = p
+			continue
+		}
+		if m := houseOnly.FindStringSubmatch(p); c.HouseNumber == "" && m != nil {
+			c.HouseNumber = m[1]
+			continue
+		}
+		rest = append(rest, p)
+	}
+	if len(rest) == 0 {
+		return c
+	}
+
+	// 4. Street line: house number, street and an inline unit
+	street := rest[0]
+	if c.Unit == "" {
+		if loc := unitPattern.FindStringSubmatchIndex(street); loc != nil {
+			c.Unit = strings.TrimSpace(street[loc[2]:loc[3]])
+			street = strings.TrimSpace(street[:loc[0]])
+		}
+	}
+	// When the house number came as its own part the whole line is the street
+	if c.HouseNumber == "" {
+		if m := leadingNumber.FindStringSubmatch(street); m != nil {
+			c.HouseNumber, street = m[1], m[2]
+		} else if m := trailingNumber.FindStringSubmatch(street); m != nil {
+			street, c.HouseNumber = m[1], m[2]
+		}
+	}
+	c.Street = street
+
+	// 5. Locality and region from what is left, e.g. "Springfield", "IL".
+	// Parts before the locality ("Dhanmondi, Dhaka, ...") belong to it.
+	rest = rest[1:]
+	switch {
+	case len(rest) == 1:
+		if isUSState(rest[0]) {
+			c.Region = strings.ToUpper(rest[0])
+		} else {
+			c.Locality = rest[0]
+		}
+	case len(rest) >= 2:
+		c.Locality = strings.Join(rest[:len(rest)-1], ", ")
+		c.Region = rest[len(rest)-1]
+		if isUSState(c.Region) {
+			c.Region = strings.ToUpper(c.Region)
+		}
+	}
+
+	if c.CountryCode == "" && isUSState(c.Region) && c.PostalCode != "" {
+		c.CountryCode = "US"
+	}
+	return c
+}
+
+// trailingCountry returns the country named by the last part. A bare code
+// that is also a US state ("CA", "DE", "IN") is only taken as a country when
+// the part before it already holds the region or postal code, as in
+// "Springfield, IL 62701, US"; in "Sacramento, CA" it stays the region.
+func trailingCountry(parts []string) (string, bool) {
+	last := parts[len(parts)-1]
+	if code, ok := countryCode(last); ok {
+		return code, true
+	}
+	code, ok := isoCountryCode(last)
+	if !ok {
+		return "", false
+	}
+	if !isUSState(code) {
+		return code, true
+	}
+	prev := parts[len(parts)-2]
+	if isUSState(prev) {
+		return code, true
+	}
+	for _, re := range postalPatterns {
+		if re.MatchString(prev) {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// Merge fills the empty fields of supplied from parsed, so explicitly sent
+// components always win.
+func Merge(supplied, parsed domain.Components) domain.Components {
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fill(&supplied.HouseNumber, parsed.HouseNumber)
+	fill(&supplied.Street, parsed.Street)
+	fill(&supplied.Unit, parsed.Unit)
+	fill(&supplied.Locality, parsed.Locality)
+	fill(&supplied.Region, parsed.Region)
+	fill(&supplied.PostalCode, parsed.PostalCode)
+	fill(&supplied.CountryCode, parsed.CountryCode)
+	return supplied
+}
@@ -2,20 +2,54 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
-	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/imimran/go-grpc-auth/address/domain"
+	"github.com/imimran/go-grpc-auth/pagination"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AddressRepository interface {
 	Create(ctx context.Context, addr *domain.Address) error
+	// CreateBatch inserts addrs, skipping those whose owner already has an
+	// address with the same normalized_address, and returns the ids that
+	// were inserted.
+	CreateBatch(ctx context.Context, addrs []domain.Address) (map[uuid.UUID]bool, error)
 	FindByID(ctx context.Context, id string) (*domain.Address, error)
 	Update(ctx context.Context, addr *domain.Address) error
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, page, limit int) ([]domain.Address, int64, error)
+	List(ctx context.Context, filter domain.AddressFilter, page, limit int) ([]domain.Address, int64, error)
+	// ListPage returns up to limit addresses newest first, starting after
+	// the cursor when it is set and skipping offset rows otherwise.
+	ListPage(ctx context.Context, filter domain.AddressFilter, after *pagination.Cursor, offset, limit int) ([]domain.Address, error)
+	Count(ctx context.Context, filter domain.AddressFilter) (int64, error)
+	SearchNearby(ctx context.Context, filter domain.AddressFilter, center domain.Coordinates, radiusMeters float64, limit int) ([]domain.NearbyAddress, error)
+	// ListInBoundingBox and ListInPolygon count at most maxResults matches and
+	// never page past that cap.
+	ListInBoundingBox(ctx context.Context, filter domain.AddressFilter, box domain.BoundingBox, page, limit, maxResults int) ([]domain.Address, int64, error)
+	ListInPolygon(ctx context.Context, filter domain.AddressFilter, polygon domain.Polygon, page, limit, maxResults int) ([]domain.Address, int64, error)
+	// ListStaleNormalized returns addresses normalized by a version other than
+	// version or a locale other than locale, ordered by id and starting after
+	// the given id.
+	ListStaleNormalized(ctx context.Context, version int, locale string, after uuid.UUID, limit int) ([]domain.Address, error)
+	UpdateNormalized(ctx context.Context, id uuid.UUID, normalized string, version int, locale string) error
+	// ListAfter returns addresses matching filter ordered by id, starting
+	// after the given id, for walking the whole table in batches.
+	ListAfter(ctx context.Context, filter domain.AddressFilter, after uuid.UUID, limit int) ([]domain.Address, error)
+
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.Address, error)
+	FindDuplicatePairs(ctx context.Context, query domain.DuplicateQuery) ([]domain.DuplicatePair, error)
+	// Merge saves survivor, records and deletes duplicates, and redirects
+	// earlier merges into the duplicates to the survivor, in one transaction.
+	Merge(ctx context.Context, survivor *domain.Address, duplicates []domain.Address, mergedBy int64) error
+	// FindMergedInto returns the survivor id an address was merged into, or
+	// domain.ErrAddressNotFound.
+	FindMergedInto(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
 }
 
 type addressRepo struct {
@@ -28,16 +62,53 @@ func NewAddressRepo(db *gorm.DB) AddressRepository {
 
 // Create persists a new address to the database
 func (r *addressRepo) Create(ctx context.Context, addr *domain.Address) error {
-	return r.db.WithContext(ctx).Create(addr).Error
+	err := r.db.WithContext(ctx).Create(addr).Error
+	if isUniqueViolation(err) {
+		return domain.ErrDuplicateAddress
+	}
+	return err
 }
 
-func (r *addressRepo) List(ctx context.Context, page, limit int) ([]domain.Address, int64, error) {
+func (r *addressRepo) CreateBatch(ctx context.Context, addrs []domain.Address) (map[uuid.UUID]bool, error) {
+	inserted := make(map[uuid.UUID]bool, len(addrs))
+	if len(addrs) == 0 {
+		return inserted, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(addrs))
+	for i := range addrs {
+		ids = append(ids, addrs[i].ID)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "normalized_address"}},
+			DoNothing: true,
+		}).Create(&addrs).Error; err != nil {
+			return err
+		}
+
+		// ON CONFLICT DO NOTHING does not say which rows were skipped; the
+		// ids are fresh, so the ones present now are the ones just written
+		var found []uuid.UUID
+		if err := tx.Model(&domain.Address{}).Where("id IN ?", ids).Pluck("id", &found).Error; err != nil {
+			return err
+		}
+		for _, id := range found {
+			inserted[id] = true
+		}
+		return nil
+	})
+	return inserted, err
+}
+
+func (r *addressRepo) List(ctx context.Context, filter domain.AddressFilter, page, limit int) ([]domain.Address, int64, error) {
 	var addresses []domain.Address
 	var total int64
 
 	// 1. Get total count using a fresh Model session
 	// This ensures Count() doesn't interfere with the subsequent Find()
-	if err := r.db.WithContext(ctx).Model(&domain.Address{}).Count(&total).Error; err != nil {
+	if err := applyFilter(r.db.WithContext(ctx).Model(&domain.Address{}), filter).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -48,30 +119,262 @@ func (r *addressRepo) List(ctx context.Context, page, limit int) ([]domain.Addre
 
 	// 2. Fetch the records using a fresh session
 	offset := (page - 1) * limit
-	err := r.db.WithContext(ctx).
+	err := applyFilter(r.db.WithContext(ctx), filter).
 		Limit(limit).
 		Offset(offset).
-		Order("id DESC"). // Use ID or created_at
+		Order("created_at DESC, id DESC").
 		Find(&addresses).Error
 
 	return addresses, total, err
 }
 
-// FindByID retrieves a single address by its UUID string
-func (r *addressRepo) FindByID(ctx context.Context, id string) (*domain.Address, error) {
-	if id == "" {
-		return nil, errors.New("address id is required")
+func (r *addressRepo) ListPage(ctx context.Context, filter domain.AddressFilter, after *pagination.Cursor, offset, limit int) ([]domain.Address, error) {
+	var addresses []domain.Address
+	q := applyFilter(r.db.WithContext(ctx), filter)
+	if after != nil {
+		// Row comparison walks the (created_at, id) index
+		q = q.Where("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
+	} else if offset > 0 {
+		q = q.Offset(offset)
+	}
+	err := q.Order("created_at DESC, id DESC").Limit(limit).Find(&addresses).Error
+	return addresses, err
+}
+
+func (r *addressRepo) Count(ctx context.Context, filter domain.AddressFilter) (int64, error) {
+	var total int64
+	err := applyFilter(r.db.WithContext(ctx).Model(&domain.Address{}), filter).Count(&total).Error
+	return total, err
+}
+
+// applyFilter adds a WHERE clause for every non-zero filter field
+func applyFilter(q *gorm.DB, filter domain.AddressFilter) *gorm.DB {
+	if filter.UserID != 0 {
+		q = q.Where("user_id = ?", filter.UserID)
+	}
+
+	c := filter.Components
+	for _, f := range []struct{ column, value string }{
+		{"house_number", c.HouseNumber},
+		{"street", c.Street},
+		{"unit", c.Unit},
+		{"locality", c.Locality},
+		{"region", c.Region},
+		{"postal_code", c.PostalCode},
+		{"country_code", c.CountryCode},
+	} {
+		if f.value != "" {
+			q = q.Where("lower("+f.column+") = lower(?)", f.value)
+		}
+	}
+	return q
+}
+
+// SearchNearby returns addresses within radiusMeters of center, closest first.
+// ST_DWithin on the geography column is served by the GIST index.
+func (r *addressRepo) SearchNearby(ctx context.Context, filter domain.AddressFilter, center domain.Coordinates, radiusMeters float64, limit int) ([]domain.NearbyAddress, error) {
+	point := gorm.Expr("ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography", center.Longitude, center.Latitude)
+
+	var results []domain.NearbyAddress
+	err := applyFilter(r.db.WithContext(ctx).Model(&domain.Address{}), filter).
+		Select("addresses.*, ST_Distance(geom, ?) AS distance_meters", point).
+		Where("ST_DWithin(geom, ?, ?)", point, radiusMeters).
+		Order("distance_meters, id").
+		Limit(limit).
+		Scan(&results).Error
+
+	return results, err
+}
+
+func (r *addressRepo) ListInBoundingBox(ctx context.Context, filter domain.AddressFilter, box domain.BoundingBox, page, limit, maxResults int) ([]domain.Address, int64, error) {
+	// && on the geography column uses the GIST index; ST_Intersects on the
+	// planar envelope then matches exactly what a map viewport shows.
+	var parts []string
+	var args []interface{}
+	for _, env := range box.Envelopes() {
+		parts = append(parts, "(geom && ST_MakeEnvelope(?, ?, ?, ?, 4326)::geography AND ST_Intersects(geom::geometry, ST_MakeEnvelope(?, ?, ?, ?, 4326)))")
+		e := []interface{}{env.SouthWest.Longitude, env.SouthWest.Latitude, env.NorthEast.Longitude, env.NorthEast.Latitude}
+		args = append(args, e...)
+		args = append(args, e...)
+	}
+	where := gorm.Expr(strings.Join(parts, " OR "), args...)
+
+	return r.listWhere(ctx, filter, where, page, limit, maxResults)
+}
+
+func (r *addressRepo) ListInPolygon(ctx context.Context, filter domain.AddressFilter, polygon domain.Polygon, page, limit, maxResults int) ([]domain.Address, int64, error) {
+	where := gorm.Expr("ST_Intersects(geom, ST_GeomFromText(?, 4326)::geography)", polygon.WKT())
+	return r.listWhere(ctx, filter, where, page, limit, maxResults)
+}
+
+// listWhere pages through addresses matching a spatial condition. The count
+// stops at maxResults+1 so huge areas don't cost a full count.
+func (r *addressRepo) listWhere(ctx context.Context, filter domain.AddressFilter, where clause.Expr, page, limit, maxResults int) ([]domain.Address, int64, error) {
+	matching := func() *gorm.DB {
+		return applyFilter(r.db.WithContext(ctx).Model(&domain.Address{}), filter).Where(where)
+	}
+
+	var total int64
+	capped := matching().Select("1").Limit(maxResults + 1)
+	if err := r.db.WithContext(ctx).Table("(?) AS capped", capped).Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
 
+	offset := (page - 1) * limit
+	if total == 0 || offset >= maxResults {
+		return []domain.Address{}, total, nil
+	}
+	if offset+limit > maxResults {
+		limit = maxResults - offset
+	}
+
+	var addresses []domain.Address
+	err := matching().
+		Order("id").
+		Offset(offset).
+		Limit(limit).
+		Find(&addresses).Error
+
+	return addresses, total, err
+}
+
+func (r *addressRepo) ListStaleNormalized(ctx context.Context, version int, locale string, after uuid.UUID, limit int) ([]domain.Address, error) {
+	var addresses []domain.Address
+	err := r.db.WithContext(ctx).
+		Where("(normalizer_version <> ? OR normalizer_locale <> ?) AND id > ?", version, locale, after).
+		Order("id").
+		Limit(limit).
+		Find(&addresses).Error
+	return addresses, err
+}
+
+func (r *addressRepo) ListAfter(ctx context.Context, filter domain.AddressFilter, after uuid.UUID, limit int) ([]domain.Address, error) {
+	var addresses []domain.Address
+	err := applyFilter(r.db.WithContext(ctx), filter).
+		Where("id > ?", after).
+		Order("id").
+		Limit(limit).
+		Find(&addresses).Error
+	return addresses, err
+}
+
+func (r *addressRepo) UpdateNormalized(ctx context.Context, id uuid.UUID, normalized string, version int, locale string) error {
+	err := r.db.WithContext(ctx).Model(&domain.Address{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"normalized_address": normalized,
+			"normalizer_version": version,
+			"normalizer_locale":  locale,
+		}).Error
+	if isUniqueViolation(err) {
+		return domain.ErrDuplicateAddress
+	}
+	return err
+}
+
+func (r *addressRepo) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]domain.Address, error) {
+	var addresses []domain.Address
+	if len(ids) == 0 {
+		return addresses, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&addresses).Error
+	return addresses, err
+}
+
+// FindDuplicatePairs self-joins addresses within MaxDistanceMeters of each
+// other (GIST index) and keeps pairs whose trigram similarity is high enough.
+func (r *addressRepo) FindDuplicatePairs(ctx context.Context, query domain.DuplicateQuery) ([]domain.DuplicatePair, error) {
+	q := r.db.WithContext(ctx).
+		Table("addresses AS a").
+		Select(`a.id AS a, b.id AS b,
+			similarity(a.normalized_address, b.normalized_address) AS similarity,
+			ST_Distance(a.geom, b.geom) AS distance_meters`).
+		// Only addresses of the same owner can be merged, so only they pair up
+		Joins("JOIN addresses AS b ON a.id < b.id AND a.user_id = b.user_id AND ST_DWithin(a.geom, b.geom, ?)", query.MaxDistanceMeters).
+		Where("similarity(a.normalized_address, b.normalized_address) >= ?", query.MinSimilarity)
+	if query.UserID != 0 {
+		q = q.Where("a.user_id = ?", query.UserID)
+	}
+
+	var pairs []domain.DuplicatePair
+	err := q.Order("similarity DESC, distance_meters").
+		Limit(query.MaxPairs).
+		Scan(&pairs).Error
+	return pairs, err
+}
+
+func (r *addressRepo) Merge(ctx context.Context, survivor *domain.Address, duplicates []domain.Address, mergedBy int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		mergedIDs := make([]uuid.UUID, 0, len(duplicates))
+		for i := range duplicates {
+			dup := &duplicates[i]
+			if dup.UserID != survivor.UserID {
+				return domain.ErrInvalidMerge.WithMessage("cannot merge addresses of different owners")
+			}
+			snapshot, err := json.Marshal(dup)
+			if err != nil {
+				return err
+			}
+			if err := tx.Create(&domain.AddressMerge{
+				SurvivorID:   survivor.ID,
+				MergedID:     dup.ID,
+				MergedUserID: dup.UserID,
+				Snapshot:     string(snapshot),
+				MergedBy:     mergedBy,
+			}).Error; err != nil {
+				return err
+			}
+			mergedIDs = append(mergedIDs, dup.ID)
+		}
+
+		// Ids merged into a duplicate earlier now resolve to the survivor
+		if err := tx.Model(&domain.AddressMerge{}).
+			Where("survivor_id IN ?", mergedIDs).
+			Update("survivor_id", survivor.ID).Error; err != nil {
+			return err
+		}
+
+		// Free the duplicates' normalized_address before the survivor may take it
+		if err := tx.Delete(&domain.Address{}, "id IN ?", mergedIDs).Error; err != nil {
+			return err
+		}
+
+		err := tx.Save(survivor).Error
+		if isUniqueViolation(err) {
+			return domain.ErrDuplicateAddress
+		}
+		return err
+	})
+}
+
+func (r *addressRepo) FindMergedInto(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	var merge domain.AddressMerge
+	err := r.db.WithContext(ctx).Select("survivor_id").First(&merge, "merged_id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return uuid.Nil, domain.ErrAddressNotFound
+	}
+	return merge.SurvivorID, err
+}
+
+// isUniqueViolation reports a Postgres unique_violation (SQLSTATE 23505)
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// FindByID retrieves a single address by its UUID string
+func (r *addressRepo) FindByID(ctx context.Context, id string) (*domain.Address, error) {
 	// Validate if the incoming string is a real UUID before querying DB
 	parsedID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid uuid format: %w", err)
+		return nil, domain.ErrInvalidAddressID
 	}
 
 	var addr domain.Address
-	// Using .First returns gorm.ErrRecordNotFound if the ID doesn't exist
 	err = r.db.WithContext(ctx).First(&addr, "id = ?", parsedID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrAddressNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -80,15 +383,19 @@ func (r *addressRepo) FindByID(ctx context.Context, id string) (*domain.Address,
 
 // Update modifies an existing address record using the struct's ID
 func (r *addressRepo) Update(ctx context.Context, addr *domain.Address) error {
-	// .Save() performs an UPSERT. If ID exists, it updates; if not, it inserts.
-	result := r.db.WithContext(ctx).Save(addr)
+	// Not Save: it falls back to an insert when no row matches, which would
+	// bring deleted or merged addresses back
+	result := r.db.WithContext(ctx).Model(&domain.Address{}).Where("id = ?", addr.ID).Select("*").Updates(addr)
+	if isUniqueViolation(result.Error) {
+		return domain.ErrDuplicateAddress
+	}
 	if result.Error != nil {
 		return result.Error
 	}
 
 	// If no rows were affected, the ID provided didn't exist in the database
 	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+		return domain.ErrAddressNotFound
 	}
 	return nil
 }
@@ -97,7 +404,7 @@ func (r *addressRepo) Update(ctx context.Context, addr *domain.Address) error {
 func (r *addressRepo) Delete(ctx context.Context, id string) error {
 	parsedID, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf("invalid uuid format: %w", err)
+		return domain.ErrInvalidAddressID
 	}
 
 	// Delete requires a model type and the condition
@@ -108,7 +415,7 @@ func (r *addressRepo) Delete(ctx context.Context, id string) error {
 
 	// Return Not Found error if the ID was not present in the DB
 	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+		return domain.ErrAddressNotFound
 	}
 	return nil
 }
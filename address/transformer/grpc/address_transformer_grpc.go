@@ -21,11 +21,123 @@ func ToProtoAddress(addr *domain.Address) *pb.Address {
 			Latitude:  addr.Coordinates.Latitude,
 			Longitude: addr.Coordinates.Longitude,
 		},
-		Accuracy: addr.Accuracy,
-		Source:   addr.Source,
+		Accuracy:   addr.Accuracy,
+		Source:     addr.Source,
+		UserId:     addr.UserID,
+		GeocodedBy: addr.GeocodedBy,
+		Components: ToProtoComponents(addr.Components),
 	}
 }
 
+func ToProtoComponents(c domain.Components) *pb.AddressComponents {
+	return &pb.AddressComponents{
+		HouseNumber: c.HouseNumber,
+		Street:      c.Street,
+		Unit:        c.Unit,
+		Locality:    c.Locality,
+		Region:      c.Region,
+		PostalCode:  c.PostalCode,
+		CountryCode: c.CountryCode,
+	}
+}
+
+// ToDomainComponents converts protobuf components; nil means none supplied
+func ToDomainComponents(c *pb.AddressComponents) domain.Components {
+	return domain.Components{
+		HouseNumber: c.GetHouseNumber(),
+		Street:      c.GetStreet(),
+		Unit:        c.GetUnit(),
+		Locality:    c.GetLocality(),
+		Region:      c.GetRegion(),
+		PostalCode:  c.GetPostalCode(),
+		CountryCode: c.GetCountryCode(),
+	}
+}
+
+// ToDomainAddressFilter reads the component filters of a list request
+func ToDomainAddressFilter(req *pb.AddressListRequest) domain.AddressFilter {
+	return domain.AddressFilter{
+		Components: domain.Components{
+			HouseNumber: req.GetHouseNumber(),
+			Street:      req.GetStreet(),
+			Unit:        req.GetUnit(),
+			Locality:    req.GetLocality(),
+			Region:      req.GetRegion(),
+			PostalCode:  req.GetPostalCode(),
+			CountryCode: req.GetCountryCode(),
+		},
+	}
+}
+
+// ToDomainExportFilter reads the same component filters as
+// ToDomainAddressFilter, plus the owner an admin may export for.
+func ToDomainExportFilter(req *pb.ExportAddressesRequest) domain.AddressFilter {
+	return domain.AddressFilter{
+		UserID: req.GetUserId(),
+		Components: domain.Components{
+			HouseNumber: req.GetHouseNumber(),
+			Street:      req.GetStreet(),
+			Unit:        req.GetUnit(),
+			Locality:    req.GetLocality(),
+			Region:      req.GetRegion(),
+			PostalCode:  req.GetPostalCode(),
+			CountryCode: req.GetCountryCode(),
+		},
+	}
+}
+
+// ToDomainCoordinates converts a protobuf coordinate pair; nil becomes (0, 0)
+func ToDomainCoordinates(c *pb.Coordinates) domain.Coordinates {
+	return domain.Coordinates{
+		Latitude:  c.GetLatitude(),
+		Longitude: c.GetLongitude(),
+	}
+}
+
+// ToProtoNearbyAddressList converts search hits, keeping their distance from the center
+func ToProtoNearbyAddressList(results []domain.NearbyAddress) []*pb.NearbyAddress {
+	out := make([]*pb.NearbyAddress, 0, len(results))
+	for i := range results {
+		out = append(out, &pb.NearbyAddress{
+			Address:        ToProtoAddress(&results[i].Address),
+			DistanceMeters: results[i].DistanceMeters,
+		})
+	}
+	return out
+}
+
+// ToProtoReverseGeocodeResponse converts a reverse geocoding match. Unsaved
+// geocoder candidates are returned without an id.
+func ToProtoReverseGeocodeResponse(result *domain.ReverseGeocodeResult) *pb.ReverseGeocodeResponse {
+	address := ToProtoAddress(&result.Address)
+	if !result.Stored {
+		address.Id = ""
+	}
+	return &pb.ReverseGeocodeResponse{
+		Success:        true,
+		Message:        "Address resolved successfully",
+		Address:        address,
+		DistanceMeters: result.DistanceMeters,
+		Confidence:     result.Confidence,
+		Stored:         result.Stored,
+	}
+}
+
+func ToProtoDuplicateClusters(clusters []domain.DuplicateCluster) []*pb.DuplicateCluster {
+	out := make([]*pb.DuplicateCluster, 0, len(clusters))
+	for _, c := range clusters {
+		cluster := &pb.DuplicateCluster{
+			MinSimilarity:     c.MinSimilarity,
+			MaxDistanceMeters: c.MaxDistanceMeters,
+		}
+		for i := range c.Addresses {
+			cluster.Addresses = append(cluster.Addresses, ToProtoAddress(&c.Addresses[i]))
+		}
+		out = append(out, cluster)
+	}
+	return out
+}
+
 // ToProtoAddressList converts a slice of Domain Addresses to a slice of Protobuf Addresses
 // func ToProtoAddress(addr *domain.Address) *pb.Address {
 //     if addr == nil {
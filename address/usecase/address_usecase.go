@@ -2,23 +2,98 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/imimran/go-grpc-auth/address/domain"
+	"github.com/imimran/go-grpc-auth/address/normalize"
+	"github.com/imimran/go-grpc-auth/address/parser"
 	"github.com/imimran/go-grpc-auth/address/repository"
+	"github.com/imimran/go-grpc-auth/pagination"
 )
 
 type AddressUsecase struct {
-	repo repository.AddressRepository
+	repo       repository.AddressRepository
+	geocoder   domain.Geocoder // optional; nil disables geocoding
+	normalizer *normalize.Normalizer
+
+	reverseTolerance      float64       // default stored-address tolerance for ReverseGeocode, in meters
+	importGeocodeInterval time.Duration // minimum gap between geocoder lookups of an import
+}
+
+// Options holds the optional collaborators and settings of AddressUsecase
+type Options struct {
+	Geocoder               domain.Geocoder
+	Normalizer             *normalize.Normalizer // defaults to the "en" rules
+	ReverseToleranceMeters float64
+	ImportGeocodeRate      float64 // lookups per second during imports; DefaultImportGeocodeRate when 0
+}
+
+func NewAddressUsecase(r repository.AddressRepository, opts Options) *AddressUsecase {
+	if opts.Normalizer == nil {
+		opts.Normalizer, _ = normalize.New(normalize.DefaultLocale)
+	}
+	if opts.ImportGeocodeRate <= 0 {
+		opts.ImportGeocodeRate = DefaultImportGeocodeRate
+	}
+	return &AddressUsecase{
+		repo:                  r,
+		geocoder:              opts.Geocoder,
+		normalizer:            opts.Normalizer,
+		reverseTolerance:      opts.ReverseToleranceMeters,
+		importGeocodeInterval: time.Duration(float64(time.Second) / opts.ImportGeocodeRate),
+	}
+}
+
+// List returns one page of addresses, newest first. A page token continues
+// after the previous page; without one query.Page selects it by offset.
+func (u *AddressUsecase) List(ctx context.Context, requester domain.Requester, filter domain.AddressFilter, query pagination.Query) (*pagination.Page[domain.Address], error) {
+	// Non-admins only ever list their own addresses
+	filter.UserID = scopeFilter(requester).UserID
+
+	after, err := pagination.Decode(query.Token)
+	if err != nil {
+		return nil, err
+	}
+	limit := pagination.Limit(query.Limit)
+
+	// 1. Fetch one extra row to learn whether another page follows
+	rows, err := u.repo.ListPage(ctx, filter, after, query.Offset(), limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &pagination.Page[domain.Address]{}
+	page.Items, page.NextPageToken = pagination.Trim(rows, limit, addressCursor)
+	if !query.Keyset() {
+		page.Page = max(query.Page, 1)
+	}
+
+	// 2. The COUNT(*) scans every match, so it only runs when wanted
+	if query.CountTotal() {
+		if page.Total, err = u.repo.Count(ctx, filter); err != nil {
+			return nil, err
+		}
+		page.Counted = true
+	}
+	return page, nil
 }
 
-func NewAddressUsecase(r repository.AddressRepository) *AddressUsecase {
-	return &AddressUsecase{repo: r}
+func addressCursor(a domain.Address) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: a.CreatedAt, ID: a.ID.String()}
 }
 
-func (u *AddressUsecase) List(ctx context.Context, page, limit int) ([]domain.Address, int64, error) {
+// ListByUser lists the addresses owned by userID. Access is restricted to
+// admins by the delivery policy.
+func (u *AddressUsecase) ListByUser(ctx context.Context, userID int64, page, limit int) ([]domain.Address, int64, error) {
+	return u.list(ctx, domain.AddressFilter{UserID: userID}, page, limit)
+}
+
+func (u *AddressUsecase) list(ctx context.Context, filter domain.AddressFilter, page, limit int) ([]domain.Address, int64, error) {
 	// 1. Business Logic: Prevent extreme limits
 	if limit > 100 {
 		limit = 100
@@ -28,7 +103,7 @@ func (u *AddressUsecase) List(ctx context.Context, page, limit int) ([]domain.Ad
 	}
 
 	// 2. Call Repository to get data and total count
-	addresses, total, err := u.repo.List(ctx, page, limit)
+	addresses, total, err := u.repo.List(ctx, filter, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -36,40 +111,554 @@ func (u *AddressUsecase) List(ctx context.Context, page, limit int) ([]domain.Ad
 	return addresses, total, nil
 }
 
-func (u *AddressUsecase) Create(ctx context.Context, addr *domain.Address) error {
+// MaxSearchRadiusMeters bounds nearby searches so a single query cannot scan the whole table
+const MaxSearchRadiusMeters = 50_000
+
+// SearchNearby finds the requester's addresses (all addresses for admins)
+// within radiusMeters of center, closest first.
+func (u *AddressUsecase) SearchNearby(ctx context.Context, requester domain.Requester, center domain.Coordinates, radiusMeters float64, limit int) ([]domain.NearbyAddress, error) {
+	if radiusMeters > MaxSearchRadiusMeters {
+		radiusMeters = MaxSearchRadiusMeters
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return u.repo.SearchNearby(ctx, scopeFilter(requester), center, radiusMeters, limit)
+}
+
+// MaxAreaResults is the hard cap on matches returned by bounding box and
+// polygon queries, across all pages.
+const MaxAreaResults = 10_000
+
+func (u *AddressUsecase) ListInBoundingBox(ctx context.Context, requester domain.Requester, box domain.BoundingBox, page, limit int) ([]domain.Address, int64, error) {
+	if err := box.Validate(); err != nil {
+		return nil, 0, err
+	}
+	page, limit = areaPage(page, limit)
+	return u.repo.ListInBoundingBox(ctx, scopeFilter(requester), box, page, limit, MaxAreaResults)
+}
+
+func (u *AddressUsecase) ListInPolygon(ctx context.Context, requester domain.Requester, polygon domain.Polygon, page, limit int) ([]domain.Address, int64, error) {
+	if err := polygon.Validate(); err != nil {
+		return nil, 0, err
+	}
+	page, limit = areaPage(page, limit)
+	return u.repo.ListInPolygon(ctx, scopeFilter(requester), polygon, page, limit, MaxAreaResults)
+}
+
+// areaPage allows larger pages than List since map views load many pins at once
+func areaPage(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	return page, limit
+}
+
+// scopeFilter restricts non-admin requesters to their own addresses
+func scopeFilter(requester domain.Requester) domain.AddressFilter {
+	filter := domain.AddressFilter{}
+	if !requester.IsAdmin {
+		filter.UserID = requester.UserID
+	}
+	return filter
+}
+
+func (u *AddressUsecase) Create(ctx context.Context, requester domain.Requester, addr *domain.Address) error {
+	if err := u.prepare(ctx, requester, addr); err != nil {
+		return err
+	}
+	return u.repo.Create(ctx, addr)
+}
+
+// prepare fills in everything a new address needs before it is stored
+func (u *AddressUsecase) prepare(ctx context.Context, requester domain.Requester, addr *domain.Address) error {
 	// 1. Generate a new valid UUID (Fixes the 0000... error)
 	addr.ID = uuid.New()
 
-	// 2. Implement requirement: normalized_address is raw_address
-	// We lowercase and trim it to make the uniqueIndex effective
-	addr.NormalizedAddress = strings.ToLower(strings.TrimSpace(addr.RawAddress))
+	// 2. The owner is the caller; only admins may create on behalf of someone else
+	if addr.UserID == 0 || !requester.IsAdmin {
+		addr.UserID = requester.UserID
+	}
+
+	// 3. Normalize so spelling variants of one address hit the uniqueIndex
+	u.normalize(addr)
+	if err := fillComponents(addr); err != nil {
+		return err
+	}
+
+	// 4. Geocode raw_address when the client sent no coordinates
+	if err := u.resolveCoordinates(ctx, addr); err != nil {
+		return err
+	}
 
-	// 3. Set the PostGIS Geom field (Long then Lat)
+	// 5. Set the PostGIS Geom field (Long then Lat)
 	addr.Geom = fmt.Sprintf("SRID=4326;POINT(%f %f)",
 		addr.Coordinates.Longitude,
 		addr.Coordinates.Latitude,
 	)
+	return nil
+}
 
-	return u.repo.Create(ctx, addr)
+// MaxReverseToleranceMeters caps the per-request tolerance of ReverseGeocode
+const MaxReverseToleranceMeters = 1000
+
+// ReverseGeocode finds the address nearest to point. Stored addresses the
+// requester can see within toleranceMeters win; otherwise the geocoder is
+// asked. A zero tolerance uses the configured default.
+func (u *AddressUsecase) ReverseGeocode(ctx context.Context, requester domain.Requester, point domain.Coordinates, toleranceMeters float64) (*domain.ReverseGeocodeResult, error) {
+	if toleranceMeters <= 0 {
+		toleranceMeters = u.reverseTolerance
+	}
+	if toleranceMeters > MaxReverseToleranceMeters {
+		toleranceMeters = MaxReverseToleranceMeters
+	}
+
+	// 1. Closest stored address within tolerance
+	if toleranceMeters > 0 {
+		hits, err := u.repo.SearchNearby(ctx, scopeFilter(requester), point, toleranceMeters, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(hits) > 0 {
+			return &domain.ReverseGeocodeResult{
+				Address:        hits[0].Address,
+				DistanceMeters: hits[0].DistanceMeters,
+				Confidence:     reverseConfidence(hits[0].Accuracy, hits[0].DistanceMeters, toleranceMeters),
+				Stored:         true,
+			}, nil
+		}
+	}
+
+	// 2. Fall back to the providers; the candidate is not saved
+	if u.geocoder == nil {
+		return nil, domain.ErrNoAddressNearPoint
+	}
+	result, err := u.geocoder.Reverse(ctx, point)
+	if errors.Is(err, domain.ErrNoGeocodeResult) {
+		return nil, domain.ErrNoAddressNearPoint
+	}
+	if err != nil {
+		return nil, err
+	}
+	distance := point.DistanceTo(result.Coordinates)
+	return &domain.ReverseGeocodeResult{
+		Address: domain.Address{
+			UserID:      requester.UserID,
+			RawAddress:  result.Address,
+			Coordinates: result.Coordinates,
+			Accuracy:    result.Accuracy,
+			Source:      domain.SourceGeocoder,
+			GeocodedBy:  result.Provider,
+		},
+		DistanceMeters: distance,
+		Confidence:     reverseConfidence(result.Accuracy, distance, math.Max(toleranceMeters, 1)),
+	}, nil
 }
-func (u *AddressUsecase) GetByID(ctx context.Context, id string) (*domain.Address, error) {
-	return u.repo.FindByID(ctx, id)
+
+// accuracyWeight ranks how much a match's precision can be trusted.
+// Client-supplied accuracies are free-form, so unknown values sit mid-range.
+var accuracyWeight = map[string]float64{
+	domain.AccuracyRooftop:     1,
+	domain.AccuracyStreet:      0.8,
+	domain.AccuracyLocality:    0.5,
+	domain.AccuracyRegion:      0.3,
+	domain.AccuracyApproximate: 0.2,
+	domain.AccuracyCountry:     0.1,
+}
+
+// reverseConfidence is 1 for a rooftop match on the point and halves by the
+// time the match is toleranceMeters away.
+func reverseConfidence(accuracy string, distanceMeters, toleranceMeters float64) float64 {
+	weight, ok := accuracyWeight[accuracy]
+	if !ok {
+		weight = 0.7
+	}
+	return weight * toleranceMeters / (toleranceMeters + distanceMeters)
+}
+
+func (u *AddressUsecase) normalize(addr *domain.Address) {
+	addr.NormalizedAddress = u.normalizer.Normalize(addr.RawAddress)
+	addr.NormalizerVersion = u.normalizer.Version()
+	addr.NormalizerLocale = u.normalizer.Locale()
+}
+
+// fillComponents parses raw_address for any component the client left empty
+func fillComponents(addr *domain.Address) error {
+	addr.Components = parser.Merge(addr.Components, parser.Parse(addr.RawAddress))
+	return addr.Components.Clean()
 }
 
+// Renormalize rewrites NormalizedAddress on rows produced by an older
+// normalizer version or another locale, batchSize rows at a time. Rows whose new form collides
+// with another address are skipped and counted as conflicts; they are left
+// on their old version for duplicate review.
+func (u *AddressUsecase) Renormalize(ctx context.Context, batchSize int) (updated, conflicts int, err error) {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	after := uuid.Nil
+	for {
+		batch, err := u.repo.ListStaleNormalized(ctx, u.normalizer.Version(), u.normalizer.Locale(), after, batchSize)
+		if err != nil {
+			return updated, conflicts, err
+		}
+		if len(batch) == 0 {
+			return updated, conflicts, nil
+		}
 
-func (u *AddressUsecase) Update(ctx context.Context, addr *domain.Address) error {
-    // Re-normalize in case the RawAddress changed
-    addr.NormalizedAddress = strings.ToLower(strings.TrimSpace(addr.RawAddress))
+		for i := range batch {
+			addr := &batch[i]
+			u.normalize(addr)
+			err := u.repo.UpdateNormalized(ctx, addr.ID, addr.NormalizedAddress, addr.NormalizerVersion, addr.NormalizerLocale)
+			switch {
+			case errors.Is(err, domain.ErrDuplicateAddress):
+				conflicts++
+			case err != nil:
+				return updated, conflicts, err
+			default:
+				updated++
+			}
+		}
+		after = batch[len(batch)-1].ID
+	}
+}
 
-    // Re-calculate spatial point
-    addr.Geom = fmt.Sprintf("SRID=4326;POINT(%f %f)", 
-        addr.Coordinates.Longitude, 
-        addr.Coordinates.Latitude,
-    )
+// resolveCoordinates fills Coordinates, Accuracy and Source from the geocoder
+// when the address has none. (0, 0) counts as missing: proto omits unset
+// coordinates as zero and no deliverable address lies there.
+func (u *AddressUsecase) resolveCoordinates(ctx context.Context, addr *domain.Address) error {
+	if addr.Coordinates != (domain.Coordinates{}) {
+		if addr.Source == "" {
+			addr.Source = domain.SourceClient
+		}
+		addr.GeocodedBy = ""
+		return nil
+	}
+	if u.geocoder == nil || strings.TrimSpace(addr.RawAddress) == "" {
+		return domain.ErrCoordinatesRequired
+	}
 
-    return u.repo.Update(ctx, addr)
+	result, err := u.geocoder.Geocode(ctx, addr.RawAddress)
+	if err != nil {
+		return err
+	}
+	addr.Coordinates = result.Coordinates
+	addr.Accuracy = result.Accuracy
+	addr.Source = domain.SourceGeocoder
+	addr.GeocodedBy = result.Provider
+	return nil
+}
+
+// GetByID returns the address if the requester may see it. Addresses owned by
+// someone else are reported as not found so their existence is not leaked.
+func (u *AddressUsecase) GetByID(ctx context.Context, requester domain.Requester, id string) (*domain.Address, error) {
+	addr, err := u.repo.FindByID(ctx, id)
+	if errors.Is(err, domain.ErrAddressNotFound) {
+		// A merged address resolves to the one it was merged into
+		addr, err = u.findSurvivor(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !requester.CanAccess(addr) {
+		return nil, domain.ErrAddressNotFound
+	}
+	return addr, nil
+}
+
+func (u *AddressUsecase) findSurvivor(ctx context.Context, id string) (*domain.Address, error) {
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domain.ErrAddressNotFound
+	}
+	survivorID, err := u.repo.FindMergedInto(ctx, parsedID)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.FindByID(ctx, survivorID.String())
+}
+
+// Update saves addr over the stored address. With paths only those fields
+// (see domain.UpdatePaths) are taken from addr and the rest are kept; addr
+// then holds the stored result.
+func (u *AddressUsecase) Update(ctx context.Context, requester domain.Requester, addr *domain.Address, paths []string) error {
+	existing, err := u.GetByID(ctx, requester, addr.ID.String())
+	if err != nil {
+		return err
+	}
+	if len(paths) > 0 {
+		return u.patch(ctx, existing, addr, paths)
+	}
+
+	// A merged id writes to the survivor it resolved to. Ownership and
+	// creation time never change through an update
+	addr.ID = existing.ID
+	addr.UserID = existing.UserID
+	addr.CreatedAt = existing.CreatedAt
+
+	// Re-normalize in case the RawAddress changed
+	u.normalize(addr)
+	if err := fillComponents(addr); err != nil {
+		return err
+	}
+
+	if err := u.resolveCoordinates(ctx, addr); err != nil {
+		return err
+	}
+
+	// Re-calculate spatial point
+	addr.Geom = fmt.Sprintf("SRID=4326;POINT(%f %f)",
+		addr.Coordinates.Longitude,
+		addr.Coordinates.Latitude,
+	)
+
+	return u.repo.Update(ctx, addr)
+}
+
+// patch changes only the fields named by paths, taking their values from
+// changes, and copies the stored result back into changes.
+func (u *AddressUsecase) patch(ctx context.Context, existing, changes *domain.Address, paths []string) error {
+	updated := *existing
+	if err := updated.ApplyFields(changes, paths); err != nil {
+		return err
+	}
+
+	// 1. Derived fields follow the fields they come from
+	if domain.HasPath(paths, "raw_address") {
+		u.normalize(&updated)
+	}
+	if err := fillComponents(&updated); err != nil {
+		return err
+	}
+
+	// 2. New coordinates are the client's unless a source is sent with them;
+	// cleared ones are geocoded again
+	if domain.HasPath(paths, "coordinates") {
+		if !domain.HasPath(paths, "source") {
+			updated.Source = ""
+		}
+		if err := u.resolveCoordinates(ctx, &updated); err != nil {
+			return err
+		}
+	}
+	updated.Geom = fmt.Sprintf("SRID=4326;POINT(%f %f)",
+		updated.Coordinates.Longitude,
+		updated.Coordinates.Latitude,
+	)
+
+	if err := u.repo.Update(ctx, &updated); err != nil {
+		return err
+	}
+	*changes = updated
+	return nil
+}
+
+func (u *AddressUsecase) Delete(ctx context.Context, requester domain.Requester, id string) error {
+	// A merged id deletes the survivor it resolves to
+	existing, err := u.GetByID(ctx, requester, id)
+	if err != nil {
+		return err
+	}
+	return u.repo.Delete(ctx, existing.ID.String())
+}
+
+// Duplicate detection defaults and limits
+const (
+	DefaultDuplicateSimilarity = 0.6
+	DefaultDuplicateDistance   = 100 // meters
+	MaxDuplicateDistance       = 1000
+	maxDuplicatePairs          = 5000
+)
+
+// FindDuplicates groups addresses whose normalized text is similar and which
+// lie close together into clusters, largest first. Access is restricted to
+// admins by the delivery policy.
+func (u *AddressUsecase) FindDuplicates(ctx context.Context, query domain.DuplicateQuery, limit int) ([]domain.DuplicateCluster, error) {
+	if query.MinSimilarity <= 0 {
+		query.MinSimilarity = DefaultDuplicateSimilarity
+	}
+	if query.MinSimilarity > 1 {
+		query.MinSimilarity = 1
+	}
+	if query.MaxDistanceMeters <= 0 {
+		query.MaxDistanceMeters = DefaultDuplicateDistance
+	}
+	if query.MaxDistanceMeters > MaxDuplicateDistance {
+		query.MaxDistanceMeters = MaxDuplicateDistance
+	}
+	query.MaxPairs = maxDuplicatePairs
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	pairs, err := u.repo.FindDuplicatePairs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := clusterPairs(pairs)
+	if len(groups) > limit {
+		groups = groups[:limit]
+	}
+
+	var ids []uuid.UUID
+	for _, g := range groups {
+		ids = append(ids, g.ids...)
+	}
+	addresses, err := u.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]domain.Address, len(addresses))
+	for _, a := range addresses {
+		byID[a.ID] = a
+	}
+
+	clusters := make([]domain.DuplicateCluster, 0, len(groups))
+	for _, g := range groups {
+		cluster := domain.DuplicateCluster{MinSimilarity: g.minSimilarity, MaxDistanceMeters: g.maxDistance}
+		for _, id := range g.ids {
+			if a, ok := byID[id]; ok {
+				cluster.Addresses = append(cluster.Addresses, a)
+			}
+		}
+		if len(cluster.Addresses) > 1 {
+			clusters = append(clusters, cluster)
+		}
+	}
+	return clusters, nil
+}
+
+type pairGroup struct {
+	ids           []uuid.UUID
+	minSimilarity float64
+	maxDistance   float64
+}
+
+// clusterPairs joins pairs sharing an address (union-find) and orders the
+// resulting groups by size, then by similarity.
+func clusterPairs(pairs []domain.DuplicatePair) []pairGroup {
+	parent := map[uuid.UUID]uuid.UUID{}
+	var find func(uuid.UUID) uuid.UUID
+	find = func(id uuid.UUID) uuid.UUID {
+		if p, ok := parent[id]; ok && p != id {
+			root := find(p)
+			parent[id] = root
+			return root
+		}
+		parent[id] = id
+		return id
+	}
+	for _, p := range pairs {
+		parent[find(p.A)] = find(p.B)
+	}
+
+	byRoot := map[uuid.UUID]*pairGroup{}
+	var order []uuid.UUID
+	group := func(id uuid.UUID) *pairGroup {
+		root := find(id)
+		g, ok := byRoot[root]
+		if !ok {
+			g = &pairGroup{minSimilarity: 1}
+			byRoot[root] = g
+			order = append(order, root)
+		}
+		return g
+	}
+	seen := map[uuid.UUID]bool{}
+	for _, p := range pairs {
+		g := group(p.A)
+		g.minSimilarity = math.Min(g.minSimilarity, p.Similarity)
+		g.maxDistance = math.Max(g.maxDistance, p.DistanceMeters)
+		for _, id := range []uuid.UUID{p.A, p.B} {
+			if !seen[id] {
+				seen[id] = true
+				g.ids = append(g.ids, id)
+			}
+		}
+	}
+
+	groups := make([]pairGroup, 0, len(order))
+	for _, root := range order {
+		groups = append(groups, *byRoot[root])
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if len(groups[i].ids) != len(groups[j].ids) {
+			return len(groups[i].ids) > len(groups[j].ids)
+		}
+		return groups[i].minSimilarity > groups[j].minSimilarity
+	})
+	return groups
+}
+
+// Merge folds duplicateIDs into survivorID. Components the survivor lacks
+// are taken from the duplicates, each duplicate is recorded in the merge
+// history and deleted, and lookups of its id resolve to the survivor.
+// All addresses must have the same owner, so nobody loses access to an
+// address by having it merged into someone else's.
+func (u *AddressUsecase) Merge(ctx context.Context, requester domain.Requester, survivorID string, duplicateIDs []string) (*domain.Address, error) {
+	if len(duplicateIDs) == 0 {
+		return nil, invalidMerge("duplicate_ids", "duplicate_ids is required")
+	}
+
+	survivorUUID, err := uuid.Parse(survivorID)
+	if err != nil {
+		return nil, invalidMerge("survivor_id", "invalid survivor_id")
+	}
+	survivor, err := u.GetByID(ctx, requester, survivorID)
+	if err != nil {
+		return nil, err
+	}
+	if survivor.ID != survivorUUID {
+		return nil, invalidMerge("survivor_id", fmt.Sprintf("survivor %s was itself merged into %s", survivorID, survivor.ID))
+	}
+
+	seen := map[uuid.UUID]bool{survivorUUID: true}
+	duplicates := make([]domain.Address, 0, len(duplicateIDs))
+	for _, id := range duplicateIDs {
+		dupID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, invalidMerge("duplicate_ids", fmt.Sprintf("invalid duplicate id %q", id))
+		}
+		if seen[dupID] {
+			return nil, invalidMerge("duplicate_ids", id+" is listed twice or is the survivor")
+		}
+		seen[dupID] = true
+
+		dup, err := u.repo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !requester.CanAccess(dup) {
+			return nil, domain.ErrAddressNotFound
+		}
+		if dup.UserID != survivor.UserID {
+			return nil, invalidMerge("duplicate_ids", id+" belongs to another user than the survivor")
+		}
+		duplicates = append(duplicates, *dup)
+		survivor.Components = parser.Merge(survivor.Components, dup.Components)
+	}
+
+	if err := u.repo.Merge(ctx, survivor, duplicates, requester.UserID); err != nil {
+		if errors.Is(err, domain.ErrDuplicateAddress) {
+			return nil, domain.ErrMergeConflict.Wrap(err)
+		}
+		return nil, err
+	}
+	return survivor, nil
 }
 
-func (u *AddressUsecase) Delete(ctx context.Context, id string) error {
-	return u.repo.Delete(ctx, id)
+func invalidMerge(field, description string) error {
+	return domain.ErrInvalidMerge.WithMessage("invalid merge request: %s", description).WithField(field, description)
 }
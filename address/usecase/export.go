@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/imimran/go-grpc-auth/address/domain"
+)
+
+// DefaultExportBatchSize is the number of rows read per query
+const DefaultExportBatchSize = 1000
+
+// Export calls fn for every address matching filter, in id order. Unlike
+// List there is no row cap: the table is walked in batches, so memory stays
+// flat however many rows match. Non-admins only export their own addresses.
+// Export stops at the first error from fn and returns it.
+func (u *AddressUsecase) Export(ctx context.Context, requester domain.Requester, filter domain.AddressFilter, batchSize int, fn func(*domain.Address) error) error {
+	if batchSize <= 0 {
+		batchSize = DefaultExportBatchSize
+	}
+	if !requester.IsAdmin {
+		filter.UserID = requester.UserID
+	}
+
+	after := uuid.Nil
+	for {
+		batch, err := u.repo.ListAfter(ctx, filter, after, batchSize)
+		if err != nil {
+			return err
+		}
+		for i := range batch {
+			if err := fn(&batch[i]); err != nil {
+				return err
+			}
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		after = batch[len(batch)-1].ID
+	}
+}
@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/imimran/go-grpc-auth/address/domain"
+	"github.com/imimran/go-grpc-auth/apperr"
+)
+
+// DefaultImportBatchSize is the number of rows inserted per statement
+const DefaultImportBatchSize = 500
+
+// DefaultImportGeocodeRate is how many rows per second an import geocodes;
+// public Nominatim servers allow one request per second.
+const DefaultImportGeocodeRate = 1.0
+
+// ImportOptions tunes a single import
+type ImportOptions struct {
+	BatchSize int // DefaultImportBatchSize when 0
+	// Geocode looks up rows without coordinates, throttled to the
+	// usecase's import geocode rate. Without it such rows are rejected, so
+	// a large file cannot hold the import (and the provider) for hours.
+	Geocode bool
+}
+
+// ImportSource yields rows until io.EOF; see the importer package.
+type ImportSource interface {
+	Next() (domain.ImportRow, error)
+}
+
+// Import reads every row from source, prepares it like Create (owner,
+// normalization, components, and geocoding if opts.Geocode) and inserts
+// valid rows in batches.
+// report is called once per row, in input order. Batches written before a
+// fatal read error stay committed; the error is returned with the summary
+// so far.
+func (u *AddressUsecase) Import(ctx context.Context, requester domain.Requester, source ImportSource, opts ImportOptions, report func(domain.ImportResult)) (domain.ImportSummary, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultImportBatchSize
+	}
+	var throttle *geocodeThrottle
+	if opts.Geocode {
+		throttle = &geocodeThrottle{interval: u.importGeocodeInterval}
+	}
+
+	var summary domain.ImportSummary
+	emit := func(r domain.ImportResult) {
+		switch r.Status {
+		case domain.ImportCreated:
+			summary.Created++
+		case domain.ImportDuplicate:
+			summary.Duplicates++
+		case domain.ImportRejected:
+			summary.Rejected++
+		}
+		report(r)
+	}
+
+	// Results of a batch are held back until it is written so the report
+	// stays in input order
+	var pending []domain.ImportResult
+	var batch []domain.Address
+	inBatch := map[importKey]int{} // line of each address within the batch
+
+	flush := func() error {
+		inserted, err := u.repo.CreateBatch(ctx, batch)
+		failed := map[uuid.UUID]error{}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// One bad row (e.g. an unknown user_id) fails the whole
+			// statement; retry row by row to find it
+			log.Printf("import batch failed, retrying row by row: %v", err)
+			inserted, failed = u.createEach(ctx, batch)
+		}
+		for _, r := range pending {
+			if r.Status == domain.ImportCreated && !inserted[r.AddressID] {
+				if rowErr, ok := failed[r.AddressID]; ok {
+					r = domain.ImportResult{Line: r.Line, Status: domain.ImportRejected, Reason: rowErr.Error()}
+				} else {
+					r = domain.ImportResult{Line: r.Line, Status: domain.ImportDuplicate, Reason: "address already exists"}
+				}
+			}
+			emit(r)
+		}
+		pending, batch = pending[:0], batch[:0]
+		inBatch = map[importKey]int{}
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return summary, err
+		}
+
+		row, err := source.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if flushErr := flush(); flushErr != nil {
+				return summary, flushErr
+			}
+			return summary, fmt.Errorf("%w: %v", domain.ErrUnreadableImport, err)
+		}
+
+		addr := row.Address
+		result := domain.ImportResult{Line: row.Line}
+		err = u.prepareImportRow(ctx, requester, &row, &addr, throttle)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return summary, ctxErr
+		}
+		switch {
+		case err != nil:
+			result.Status, result.Reason = domain.ImportRejected, importReason(row.Line, err)
+		case inBatch[newImportKey(addr)] != 0:
+			result.Status = domain.ImportDuplicate
+			result.Reason = fmt.Sprintf("same address as row %d", inBatch[newImportKey(addr)])
+		default:
+			inBatch[newImportKey(addr)] = row.Line
+			batch = append(batch, addr)
+			result.Status, result.AddressID = domain.ImportCreated, addr.ID
+		}
+		pending = append(pending, result)
+
+		if len(batch) >= batchSize {
+			if err := flush(); err != nil {
+				return summary, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return summary, err
+	}
+	return summary, nil
+}
+
+// importKey identifies an address the way the unique index does: the same
+// normalized address may exist once per owner
+type importKey struct {
+	userID     int64
+	normalized string
+}
+
+func newImportKey(addr domain.Address) importKey {
+	return importKey{userID: addr.UserID, normalized: addr.NormalizedAddress}
+}
+
+// createEach inserts rows one at a time. Duplicates are neither inserted nor
+// failed; other errors are reported without database details.
+func (u *AddressUsecase) createEach(ctx context.Context, batch []domain.Address) (map[uuid.UUID]bool, map[uuid.UUID]error) {
+	inserted := map[uuid.UUID]bool{}
+	failed := map[uuid.UUID]error{}
+	for i := range batch {
+		err := u.repo.Create(ctx, &batch[i])
+		switch {
+		case err == nil:
+			inserted[batch[i].ID] = true
+		case errors.Is(err, domain.ErrDuplicateAddress):
+		default:
+			log.Printf("import row %s failed: %v", batch[i].ID, err)
+			failed[batch[i].ID] = errors.New("could not be stored")
+		}
+	}
+	return inserted, failed
+}
+
+// prepareImportRow validates and prepares one row. Rows without coordinates
+// are geocoded only with a throttle, which paces the lookups.
+func (u *AddressUsecase) prepareImportRow(ctx context.Context, requester domain.Requester, row *domain.ImportRow, addr *domain.Address, throttle *geocodeThrottle) error {
+	if row.Err != nil {
+		// Parse errors only describe the client's own file
+		return domain.ErrInvalidImportRow.WithMessage("%v", row.Err)
+	}
+	if strings.TrimSpace(addr.RawAddress) == "" {
+		return domain.ErrInvalidImportRow.WithMessage("raw_address is empty")
+	}
+	if !addr.Coordinates.Valid() {
+		return domain.ErrInvalidImportRow.WithMessage("coordinates out of range")
+	}
+	if addr.Coordinates == (domain.Coordinates{}) {
+		if throttle == nil {
+			return domain.ErrInvalidImportRow.WithMessage("coordinates are required unless geocoding is enabled")
+		}
+		if err := throttle.wait(ctx); err != nil {
+			return err
+		}
+	}
+	if err := u.prepare(ctx, requester, addr); err != nil {
+		return err
+	}
+	if addr.NormalizedAddress == "" {
+		return domain.ErrInvalidImportRow.WithMessage("raw_address has no usable content")
+	}
+	return nil
+}
+
+// geocodeThrottle spaces geocoder lookups at least interval apart
+type geocodeThrottle struct {
+	interval time.Duration
+	next     time.Time
+}
+
+func (t *geocodeThrottle) wait(ctx context.Context) error {
+	if d := time.Until(t.next); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	t.next = time.Now().Add(t.interval)
+	return nil
+}
+
+// importReason is what the report says about a rejected row: the public
+// message of typed errors, never their cause, and a bare "internal error"
+// for anything else. Causes are logged.
+func importReason(line int, err error) string {
+	var appErr *apperr.Error
+	if errors.As(err, &appErr) && appErr.Kind != apperr.KindInternal {
+		if errors.Unwrap(appErr) != nil {
+			log.Printf("import row %d rejected: %v", line, err)
+		}
+		return appErr.Message
+	}
+	log.Printf("import row %d failed: %v", line, err)
+	return "internal error"
+}
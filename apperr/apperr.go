@@ -0,0 +1,159 @@
+// Package apperr defines the typed errors the modules return instead of gRPC
+// statuses. The Interceptor turns them into statuses in one place, so
+// handlers and usecases never pick status codes themselves.
+package apperr
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kind classifies an error for clients and decides its gRPC code.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindAlreadyExists
+	KindInvalidArgument
+	KindPermissionDenied
+	KindUnauthenticated
+	// KindConflict is a write that lost against a concurrent change; the
+	// client may retry
+	KindConflict
+	KindUnavailable
+	// KindResourceExhausted is a request refused by a rate limit or lockout;
+	// RetryAfter tells the client when to try again
+	KindResourceExhausted
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not found"
+	case KindAlreadyExists:
+		return "already exists"
+	case KindInvalidArgument:
+		return "invalid argument"
+	case KindPermissionDenied:
+		return "permission denied"
+	case KindUnauthenticated:
+		return "unauthenticated"
+	case KindConflict:
+		return "conflict"
+	case KindUnavailable:
+		return "unavailable"
+	case KindResourceExhausted:
+		return "resource exhausted"
+	}
+	return "internal"
+}
+
+// FieldViolation names a request field and what is wrong with it.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Error is a classified error. Message is shown to clients as is, so it must
+// not contain internal details; those belong in the wrapped cause, which is
+// only logged.
+type Error struct {
+	Kind Kind
+	// Reason is a stable UPPER_SNAKE_CASE identifier clients can switch on
+	Reason     string
+	Message    string
+	Violations []FieldViolation
+	RetryAfter time.Duration // zero when retrying later would not help
+	cause      error
+}
+
+// New returns an error of the given kind. Modules declare their errors once
+// with it (or the helpers below) and derive variants with WithMessage,
+// WithField and Wrap.
+func New(kind Kind, reason, message string) *Error {
+	return &Error{Kind: kind, Reason: reason, Message: message}
+}
+
+func NotFound(reason, message string) *Error {
+	return New(KindNotFound, reason, message)
+}
+
+func AlreadyExists(reason, message string) *Error {
+	return New(KindAlreadyExists, reason, message)
+}
+
+func InvalidArgument(reason, message string) *Error {
+	return New(KindInvalidArgument, reason, message)
+}
+
+func PermissionDenied(reason, message string) *Error {
+	return New(KindPermissionDenied, reason, message)
+}
+
+func Unauthenticated(reason, message string) *Error {
+	return New(KindUnauthenticated, reason, message)
+}
+
+func Conflict(reason, message string) *Error {
+	return New(KindConflict, reason, message)
+}
+
+func Unavailable(reason, message string) *Error {
+	return New(KindUnavailable, reason, message)
+}
+
+func ResourceExhausted(reason, message string) *Error {
+	return New(KindResourceExhausted, reason, message)
+}
+
+// InvalidField reports a single bad request field.
+func InvalidField(field, description string) *Error {
+	return InvalidArgument("INVALID_FIELD", description).WithField(field, description)
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is matches any error with the same kind and reason, so errors.Is still
+// recognises a declared error after WithMessage or WithField.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind && t.Reason == e.Reason
+}
+
+// WithMessage returns a copy with a more specific client message.
+func (e *Error) WithMessage(format string, args ...any) *Error {
+	c := *e
+	c.Message = fmt.Sprintf(format, args...)
+	return &c
+}
+
+// WithField returns a copy that also reports field as invalid.
+func (e *Error) WithField(field, description string) *Error {
+	c := *e
+	c.Violations = append(append([]FieldViolation(nil), e.Violations...), FieldViolation{field, description})
+	return &c
+}
+
+// WithRetryAfter returns a copy telling the client to wait d before retrying.
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	c := *e
+	c.RetryAfter = d
+	return &c
+}
+
+// Wrap returns a copy carrying cause for errors.Is/As and the server log.
+func (e *Error) Wrap(cause error) *Error {
+	c := *e
+	c.cause = cause
+	return &c
+}
@@ -0,0 +1,145 @@
+package apperr
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Domain is reported in the google.rpc.ErrorInfo detail of every error.
+const Domain = "go-grpc-auth"
+
+// RetryAfterKey is the response header metadata carrying an error's
+// RetryAfter in whole seconds; the gateway sends it as the Retry-After header.
+const RetryAfterKey = "retry-after"
+
+// internalMessage replaces the text of every unclassified error
+const internalMessage = "internal error"
+
+var kindCodes = map[Kind]codes.Code{
+	KindNotFound:          codes.NotFound,
+	KindAlreadyExists:     codes.AlreadyExists,
+	KindInvalidArgument:   codes.InvalidArgument,
+	KindPermissionDenied:  codes.PermissionDenied,
+	KindUnauthenticated:   codes.Unauthenticated,
+	KindConflict:          codes.Aborted,
+	KindUnavailable:       codes.Unavailable,
+	KindResourceExhausted: codes.ResourceExhausted,
+}
+
+// Interceptor translates handler errors into gRPC statuses:
+//   - *Error becomes its kind's code with ErrorInfo and, for field
+//     violations, BadRequest details
+//   - statuses returned by handlers pass through unchanged
+//   - context errors become Canceled / DeadlineExceeded
+//   - an error's RetryAfter is sent as RetryInfo and retry-after metadata
+//   - anything else, and Internal/Unknown statuses, is logged and replaced
+//     by a bare "internal error" so database and provider text never leaks
+//
+// It must be the outermost interceptor so it also sees the others' errors.
+type Interceptor struct{}
+
+func NewInterceptor() *Interceptor {
+	return &Interceptor{}
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			if md := retryAfterHeader(err); md != nil {
+				_ = grpc.SetHeader(ctx, md)
+			}
+			return nil, translate(info.FullMethod, err)
+		}
+		return resp, nil
+	}
+}
+
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			if md := retryAfterHeader(err); md != nil {
+				// Fails once headers are out; RetryInfo still carries the delay
+				_ = ss.SetHeader(md)
+			}
+			return translate(info.FullMethod, err)
+		}
+		return nil
+	}
+}
+
+func translate(method string, err error) error {
+	var appErr *Error
+	switch {
+	case errors.As(err, &appErr):
+		if appErr.Kind != KindInternal {
+			return appErr.Status().Err()
+		}
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		if st, ok := status.FromError(err); ok && st.Code() != codes.Internal && st.Code() != codes.Unknown {
+			return err
+		}
+	}
+
+	log.Printf("%s failed: %v", method, err)
+	return status.Error(codes.Internal, internalMessage)
+}
+
+// retryAfterHeader returns the retry-after metadata for err, or nil
+func retryAfterHeader(err error) metadata.MD {
+	var appErr *Error
+	if !errors.As(err, &appErr) || appErr.Kind == KindInternal || appErr.RetryAfter <= 0 {
+		return nil
+	}
+	return metadata.Pairs(RetryAfterKey, strconv.Itoa(retryAfterSeconds(appErr.RetryAfter)))
+}
+
+// retryAfterSeconds rounds up so clients never retry too early
+func retryAfterSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// Status converts e to the status sent to clients. Details that cannot be
+// attached are dropped rather than failing the response.
+func (e *Error) Status() *status.Status {
+	code, ok := kindCodes[e.Kind]
+	if !ok {
+		return status.New(codes.Internal, internalMessage)
+	}
+	st := status.New(code, e.Message)
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: e.Reason, Domain: Domain}}
+	if len(e.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range e.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if e.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(time.Duration(retryAfterSeconds(e.RetryAfter)) * time.Second),
+		})
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails
+	}
+	return st
+}
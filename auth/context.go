@@ -0,0 +1,26 @@
+package auth
+
+import "context"
+
+type contextKey struct{}
+
+// WithClaims returns a copy of ctx carrying the verified token claims.
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, contextKey{}, claims)
+}
+
+// ClaimsFromContext extracts the verified claims placed by the interceptor.
+// The boolean is false for public RPCs or when no token was presented.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(contextKey{}).(*Claims)
+	return claims, ok
+}
+
+// UserIDFromContext extracts the authenticated user ID placed by the interceptor.
+func UserIDFromContext(ctx context.Context) (int64, bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return 0, false
+	}
+	return claims.UserID, true
+}
@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Claims mirrors the payload signed by the user usecase on Login.
+type Claims struct {
+	UserID      int64    `json:"user_id"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"perms"`
+	// IssuedAtMillis is iat in milliseconds, so a token issued right after a
+	// user-wide revocation is not mistaken for one issued before it
+	IssuedAtMillis int64 `json:"iat_ms,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Issued returns when the token was issued, as precisely as it says.
+func (c *Claims) Issued() time.Time {
+	if c.IssuedAtMillis != 0 {
+		return time.UnixMilli(c.IssuedAtMillis)
+	}
+	return c.IssuedAt.Time
+}
+
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Claims) HasPermission(permission string) bool {
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RevocationChecker reports whether a token was revoked before its expiry
+// (logout, revoke-all-sessions, password change).
+type RevocationChecker interface {
+	IsRevoked(jti string, userID int64, issuedAt time.Time) (bool, error)
+}
+
+// Interceptor validates bearer tokens on every RPC except the configured
+// public methods (e.g. "/pb.UserService/Login") and enforces the RBAC policy.
+type Interceptor struct {
+	keys          *KeySet
+	publicMethods map[string]bool
+	revocations   RevocationChecker
+	policy        Policy
+}
+
+func NewInterceptor(keys *KeySet, publicMethods []string, revocations RevocationChecker, policy Policy) *Interceptor {
+	public := make(map[string]bool, len(publicMethods))
+	for _, m := range publicMethods {
+		public[m] = true
+	}
+	return &Interceptor{keys: keys, publicMethods: public, revocations: revocations, policy: policy}
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := i.authorize(ctx, info.FullMethod, req)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authorize(ss.Context(), info.FullMethod, nil)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize returns a context carrying the caller's verified claims.
+// Public methods pass through untouched.
+func (i *Interceptor) authorize(ctx context.Context, method string, req interface{}) (context.Context, error) {
+	if i.publicMethods[method] {
+		return ctx, nil
+	}
+
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := i.parse(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+
+	revoked, err := i.revocations.IsRevoked(claims.ID, claims.UserID, claims.Issued())
+	if err != nil {
+		log.Printf("Revocation check failed: %v", err)
+		return nil, status.Error(codes.Unavailable, "unable to verify token")
+	}
+	if revoked {
+		return nil, status.Error(codes.Unauthenticated, "token has been revoked")
+	}
+
+	if err := i.policy.check(method, claims, req); err != nil {
+		return nil, err
+	}
+
+	return WithClaims(ctx, claims), nil
+}
+
+func (i *Interceptor) parse(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, i.keys.Keyfunc,
+		jwt.WithValidMethods(i.keys.ValidMethods()), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	// jti and iat are needed to look the token up in the revocation list
+	if claims.UserID == 0 || claims.ID == "" || claims.IssuedAt == nil {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
+
+// bearerToken reads the "authorization" metadata key. grpc-gateway forwards
+// the HTTP Authorization header under the same key, so both transports work.
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "authorization token is required")
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", status.Error(codes.Unauthenticated, "authorization header must be 'Bearer <token>'")
+	}
+	return strings.TrimSpace(token), nil
+}
+
+// wrappedStream overrides Context so stream handlers see the authenticated user.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/imimran/go-grpc-auth/config"
+)
+
+// Key is a single verification key, optionally able to sign.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Public  crypto.PublicKey
+	private crypto.PrivateKey
+}
+
+// KeySet holds the signing key and every key still accepted for verification.
+// Rotating keys means adding the new key, switching signing_key_id to it and
+// keeping the old public key listed until tokens signed with it have expired.
+//
+// When no keys are configured the set falls back to HS256 with the shared
+// secret. HMAC keys are never published in the JWKS.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+	secret  []byte
+}
+
+// LoadKeySet builds a KeySet from the jwt section of config.yaml.
+func LoadKeySet(cfg config.JWTConfig) (*KeySet, error) {
+	if len(cfg.Keys) == 0 {
+		if cfg.Secret == "" {
+			return nil, errors.New("jwt: either secret or keys must be configured")
+		}
+		return &KeySet{secret: []byte(cfg.Secret)}, nil
+	}
+
+	ks := &KeySet{keys: make(map[string]*Key, len(cfg.Keys))}
+	for _, kc := range cfg.Keys {
+		if kc.ID == "" {
+			return nil, errors.New("jwt: every key needs an id")
+		}
+		if _, dup := ks.keys[kc.ID]; dup {
+			return nil, fmt.Errorf("jwt: duplicate key id %q", kc.ID)
+		}
+
+		key, err := loadKey(kc)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: key %q: %w", kc.ID, err)
+		}
+		ks.keys[kc.ID] = key
+	}
+
+	signing, ok := ks.keys[cfg.SigningKeyID]
+	if !ok {
+		return nil, fmt.Errorf("jwt: signing_key_id %q does not match any configured key", cfg.SigningKeyID)
+	}
+	if signing.private == nil {
+		return nil, fmt.Errorf("jwt: signing key %q has no private_key_file", signing.ID)
+	}
+	ks.signing = signing
+
+	return ks, nil
+}
+
+// Sign signs claims with the current signing key and stamps its kid header.
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	if ks.signing == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(ks.secret)
+	}
+
+	token := jwt.NewWithClaims(ks.signing.Method, claims)
+	token.Header["kid"] = ks.signing.ID
+	return token.SignedString(ks.signing.private)
+}
+
+// Keyfunc resolves the verification key for a parsed token from its kid header.
+func (ks *KeySet) Keyfunc(t *jwt.Token) (interface{}, error) {
+	if ks.signing == nil {
+		return ks.secret, nil
+	}
+
+	kid, _ := t.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	// Guard against algorithm confusion: the token must use the key's own algorithm
+	if t.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("key %q does not accept %s", kid, t.Method.Alg())
+	}
+	return key.Public, nil
+}
+
+// ValidMethods lists the algorithms accepted by this key set.
+func (ks *KeySet) ValidMethods() []string {
+	if ks.signing == nil {
+		return []string{jwt.SigningMethodHS256.Alg()}
+	}
+
+	seen := map[string]bool{}
+	var methods []string
+	for _, key := range ks.keys {
+		if alg := key.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
+}
+
+func loadKey(kc config.JWTKeyConfig) (*Key, error) {
+	key := &Key{ID: kc.ID}
+
+	switch {
+	case kc.PrivateKeyFile != "":
+		parsed, err := readPEM(kc.PrivateKeyFile, parsePrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		key.private = parsed
+		switch k := parsed.(type) {
+		case *rsa.PrivateKey:
+			key.Public = &k.PublicKey
+		case ed25519.PrivateKey:
+			key.Public = k.Public()
+		}
+	case kc.PublicKeyFile != "":
+		parsed, err := readPEM(kc.PublicKeyFile, parsePublicKey)
+		if err != nil {
+			return nil, err
+		}
+		key.Public = parsed
+	default:
+		return nil, errors.New("private_key_file or public_key_file is required")
+	}
+
+	switch pub := key.Public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < 2048 {
+			return nil, errors.New("RSA keys must be at least 2048 bits")
+		}
+		key.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, expected RSA or Ed25519", key.Public)
+	}
+	return key, nil
+}
+
+func readPEM[T any](path string, parse func(*pem.Block) (T, error)) (T, error) {
+	var zero T
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return zero, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return zero, fmt.Errorf("%s: no PEM block found", path)
+	}
+	return parse(block)
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.PrivateKey, error) {
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported private key PEM type %q", block.Type)
+	}
+}
+
+func parsePublicKey(block *pem.Block) (crypto.PublicKey, error) {
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported public key PEM type %q", block.Type)
+	}
+}
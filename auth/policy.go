@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Rule describes who may call an RPC.
+type Rule struct {
+	// Permission required to call the method. Empty means any authenticated user.
+	Permission string
+	// AllowSelf lets callers without Permission proceed when the request
+	// targets their own user ID (requests exposing GetId() int64).
+	AllowSelf bool
+}
+
+// Policy maps full gRPC method names to their access rule. Methods without
+// a rule only require a valid token.
+type Policy map[string]Rule
+
+// MergePolicies combines the per-module policy tables.
+func MergePolicies(policies ...Policy) Policy {
+	merged := Policy{}
+	for _, p := range policies {
+		for method, rule := range p {
+			merged[method] = rule
+		}
+	}
+	return merged
+}
+
+type idRequest interface {
+	GetId() int64
+}
+
+// check enforces the rule for method. req is nil for streaming RPCs, where
+// AllowSelf cannot be evaluated and the permission is always required.
+func (p Policy) check(method string, claims *Claims, req interface{}) error {
+	rule, ok := p[method]
+	if !ok || rule.Permission == "" || claims.HasPermission(rule.Permission) {
+		return nil
+	}
+
+	if rule.AllowSelf {
+		if r, ok := req.(idRequest); ok && r.GetId() == claims.UserID {
+			return nil
+		}
+	}
+
+	return status.Errorf(codes.PermissionDenied, "missing permission %q", rule.Permission)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/imimran/go-grpc-auth/address/geocoder"
+	"github.com/imimran/go-grpc-auth/address/normalize"
+	addressRepoPkg "github.com/imimran/go-grpc-auth/address/repository"
+	addressUsecasePkg "github.com/imimran/go-grpc-auth/address/usecase"
+	"github.com/imimran/go-grpc-auth/config"
+	"gorm.io/gorm"
+)
+
+// newAddressUsecase wires the address usecase the same way for the server
+// and the CLI commands, exiting on configuration errors.
+func newAddressUsecase(cfg *config.Config, postgresDB *gorm.DB) *addressUsecasePkg.AddressUsecase {
+	addressGeocoder, err := geocoder.New(cfg.Geocoding)
+	if err != nil {
+		log.Fatalf("Geocoder setup failed: %v", err)
+	}
+	normalizer, err := normalize.New(cfg.Normalization.Locale)
+	if err != nil {
+		log.Fatalf("Normalizer setup failed: %v", err)
+	}
+
+	return addressUsecasePkg.NewAddressUsecase(addressRepoPkg.NewAddressRepo(postgresDB), addressUsecasePkg.Options{
+		Geocoder:               addressGeocoder,
+		Normalizer:             normalizer,
+		ReverseToleranceMeters: cfg.Geocoding.ReverseToleranceMeters,
+		ImportGeocodeRate:      cfg.Geocoding.ImportRate,
+	})
+}
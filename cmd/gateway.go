@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/imimran/go-grpc-auth/apperr"
+	pb "github.com/imimran/go-grpc-auth/proto"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type gatewayRegisterFunc func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error
+
+// gatewayHandlers maps each gRPC service to its generated grpc-gateway registration.
+// A service with HTTP annotations that is missing here makes serve fail at startup.
+var gatewayHandlers = map[string]gatewayRegisterFunc{
+	pb.UserService_ServiceDesc.ServiceName:    pb.RegisterUserServiceHandlerFromEndpoint,
+	pb.AddressService_ServiceDesc.ServiceName: pb.RegisterAddressServiceHandlerFromEndpoint,
+}
+
+// registerGateway mounts every service declared in protobuf.proto that has
+// google.api.http routes. The proto file is the source of truth: each such
+// service must be registered on the gRPC server and have a gateway handler.
+func registerGateway(ctx context.Context, mux *runtime.ServeMux, grpcServer *grpc.Server, endpoint string, opts []grpc.DialOption) error {
+	registered := grpcServer.GetServiceInfo()
+
+	services := pb.File_proto_protobuf_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		service := services.Get(i)
+		name := string(service.FullName())
+
+		routes := httpRoutes(service)
+		if len(routes) == 0 {
+			continue
+		}
+		if _, ok := registered[name]; !ok {
+			return fmt.Errorf("service %s has HTTP routes but is not registered on the gRPC server", name)
+		}
+		register, ok := gatewayHandlers[name]
+		if !ok {
+			return fmt.Errorf("service %s has HTTP routes but no gateway handler in gatewayHandlers", name)
+		}
+
+		if err := register(ctx, mux, endpoint, opts); err != nil {
+			return fmt.Errorf("register %s gateway: %w", name, err)
+		}
+		for _, route := range routes {
+			log.Printf("HTTP route %s", route)
+		}
+	}
+	return nil
+}
+
+// outgoingHeaderMatcher forwards gRPC response headers with the gateway's
+// usual Grpc-Metadata- prefix, except retry-after, which becomes the
+// standard HTTP Retry-After header.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == apperr.RetryAfterKey {
+		return "Retry-After", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// httpRoutes lists "VERB /path -> Service/Method" for every annotated method
+func httpRoutes(service protoreflect.ServiceDescriptor) []string {
+	var routes []string
+	methods := service.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+		rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if !ok || rule == nil {
+			continue
+		}
+		for _, r := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+			verb, path := httpPattern(r)
+			routes = append(routes, fmt.Sprintf("%-6s %s -> %s/%s", verb, path, service.Name(), method.Name()))
+		}
+	}
+	return routes
+}
+
+func httpPattern(rule *annotations.HttpRule) (string, string) {
+	switch p := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return "GET", p.Get
+	case *annotations.HttpRule_Post:
+		return "POST", p.Post
+	case *annotations.HttpRule_Put:
+		return "PUT", p.Put
+	case *annotations.HttpRule_Delete:
+		return "DELETE", p.Delete
+	case *annotations.HttpRule_Patch:
+		return "PATCH", p.Patch
+	case *annotations.HttpRule_Custom:
+		return p.Custom.GetKind(), p.Custom.GetPath()
+	default:
+		return "?", ""
+	}
+}
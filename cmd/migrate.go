@@ -1,30 +1,145 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
 
-	userDomain "github.com/imimran/go-grpc-auth/user/domain"
-	addressDomain "github.com/imimran/go-grpc-auth/address/domain" // Import your address domain
+	"github.com/imimran/go-grpc-auth/config"
+	"github.com/imimran/go-grpc-auth/infrastructure"
+	"github.com/imimran/go-grpc-auth/infrastructure/migrate"
+	"github.com/imimran/go-grpc-auth/migrations"
+	"github.com/spf13/cobra"
 	"gorm.io/gorm"
 )
 
-func AutoMigrate(db *gorm.DB) error {
-	// 1. Optional: Enable PostGIS extension if not already enabled
-	// This is required for the 'geography' type in your Address model
-	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS postgis").Error; err != nil {
-		log.Printf("Warning: Could not enable PostGIS extension: %v", err)
+var (
+	migrateUpSteps   int
+	migrateDownSteps int
+	migrateDir       string
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage versioned SQL schema migrations",
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply pending migrations (all of them unless --steps is set)",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := newMigrator().Up(context.Background(), migrateUpSteps); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Roll back the last applied migration (or --steps of them)",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := newMigrator().Down(context.Background(), migrateDownSteps); err != nil {
+			log.Fatalf("Rollback failed: %v", err)
+		}
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show applied and pending migrations",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		statuses, err := newMigrator().Status(context.Background())
+		if err != nil {
+			log.Fatalf("Status failed: %v", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+		for _, st := range statuses {
+			state, appliedAt := "pending", ""
+			if st.Applied {
+				state, appliedAt = "applied", st.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if st.Dirty {
+				state = "dirty"
+			}
+			if st.Modified {
+				state += " (modified)"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", st.Version, st.Name, state, appliedAt)
+		}
+		w.Flush()
+	},
+}
+
+var migrateCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a new empty up/down migration pair in --dir",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		paths, err := migrate.Create(migrateDir, args[0])
+		if err != nil {
+			log.Fatalf("Create failed: %v", err)
+		}
+		for _, p := range paths {
+			fmt.Println("Created", p)
+		}
+	},
+}
+
+var migrateForceCmd = &cobra.Command{
+	Use:   "force <version>",
+	Short: "Mark the schema as being at <version> without running SQL (clears dirty state)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		version, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			log.Fatalf("Invalid version %q: %v", args[0], err)
+		}
+		if err := newMigrator().Force(context.Background(), version); err != nil {
+			log.Fatalf("Force failed: %v", err)
+		}
+		log.Printf("Schema version forced to %d", version)
+	},
+}
+
+func init() {
+	migrateUpCmd.Flags().IntVar(&migrateUpSteps, "steps", 0, "number of migrations to apply (0 = all)")
+	migrateDownCmd.Flags().IntVar(&migrateDownSteps, "steps", 1, "number of migrations to roll back")
+	migrateCreateCmd.Flags().StringVar(&migrateDir, "dir", "migrations", "directory holding the SQL migration files")
+
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd, migrateCreateCmd, migrateForceCmd)
+}
+
+// newMigrator connects to the configured database for the migrate subcommands.
+func newMigrator() *migrate.Migrator {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Config load error: %v", err)
 	}
 
-	// 2. Run migrations for all models
-	err := db.AutoMigrate(
-		&userDomain.User{},
-		&addressDomain.Address{}, // Add this line
-	)
+	postgresDB, err := db.NewPostgresDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("Database connection failed: %v", err)
+	}
 
+	m, err := migratorFor(postgresDB)
 	if err != nil {
-		return err
+		log.Fatalf("Migration setup failed: %v", err)
 	}
+	return m
+}
 
-	log.Println("Auto migration complete for User and Address tables")
-	return nil
+func migratorFor(gormDB *gorm.DB) (*migrate.Migrator, error) {
+	sqlDB, err := gormDB.DB()
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(sqlDB, migrations.FS)
 }
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/imimran/go-grpc-auth/address/normalize"
+	"github.com/imimran/go-grpc-auth/config"
+	"github.com/imimran/go-grpc-auth/infrastructure"
+	"github.com/spf13/cobra"
+)
+
+var renormalizeBatchSize int
+
+var renormalizeCmd = &cobra.Command{
+	Use:   "renormalize",
+	Short: "Re-apply the current normalization rules to stored data",
+}
+
+var renormalizeAddressesCmd = &cobra.Command{
+	Use:   "addresses",
+	Short: "Recompute normalized_address for rows written by an older normalizer version or another locale",
+	Args:  cobra.NoArgs,
+	Run:   renormalizeAddresses,
+}
+
+func init() {
+	renormalizeAddressesCmd.Flags().IntVar(&renormalizeBatchSize, "batch-size", 500, "rows updated per query batch")
+	renormalizeCmd.AddCommand(renormalizeAddressesCmd)
+}
+
+func renormalizeAddresses(cmd *cobra.Command, args []string) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Config load error: %v", err)
+	}
+
+	postgresDB, err := db.NewPostgresDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("Database connection failed: %v", err)
+	}
+
+	addressUC := newAddressUsecase(cfg, postgresDB)
+
+	updated, conflicts, err := addressUC.Renormalize(context.Background(), renormalizeBatchSize)
+	if err != nil {
+		log.Fatalf("Renormalize failed after %d rows: %v", updated, err)
+	}
+
+	fmt.Printf("Renormalized %d addresses to version %d (%s)\n", updated, normalize.Version, cfg.Normalization.Locale)
+	if conflicts > 0 {
+		fmt.Printf("%d addresses collide with an existing address and were left unchanged\n", conflicts)
+	}
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/imimran/go-grpc-auth/config"
+	"github.com/imimran/go-grpc-auth/infrastructure"
+	"github.com/imimran/go-grpc-auth/user/domain"
+	"github.com/imimran/go-grpc-auth/user/repository"
+	"github.com/imimran/go-grpc-auth/user/usecase"
+	"github.com/spf13/cobra"
+)
+
+// rolesCmd manages roles directly against the database. It is mainly used to
+// bootstrap the first admin, who can then use the GrantRole/RevokeRole RPCs.
+var rolesCmd = &cobra.Command{
+	Use:   "roles",
+	Short: "Grant or revoke user roles",
+}
+
+var rolesGrantCmd = &cobra.Command{
+	Use:   "grant <email> <role>",
+	Short: "Grant a role to a user",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		changeRole(args[0], args[1], true)
+	},
+}
+
+var rolesRevokeCmd = &cobra.Command{
+	Use:   "revoke <email> <role>",
+	Short: "Revoke a role from a user",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		changeRole(args[0], args[1], false)
+	},
+}
+
+func init() {
+	rolesCmd.AddCommand(rolesGrantCmd, rolesRevokeCmd)
+}
+
+func changeRole(email, role string, grant bool) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Config load error: %v", err)
+	}
+
+	postgresDB, err := db.NewPostgresDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("Database connection failed: %v", err)
+	}
+
+	userRepo := repository.NewUserRepository(postgresDB)
+	revocationStore := usecase.NewRevocationStore(
+		repository.NewRevocationRepository(postgresDB),
+		cfg.JWT.AccessTokenTTL,
+		cfg.JWT.RevocationCacheTTL,
+	)
+	// No tokens are issued and no passwords checked from here, so the options can stay empty
+	userUsecase := usecase.NewUserUsecase(userRepo, repository.NewRefreshTokenRepository(postgresDB), revocationStore,
+		usecase.TokenOptions{}, domain.PasswordPolicy{}, domain.LockoutPolicy{})
+
+	user, err := userRepo.GetByEmail(email)
+	if err != nil {
+		log.Fatalf("User %s not found: %v", email, err)
+	}
+
+	if grant {
+		user, err = userUsecase.GrantRole(user.ID, role)
+	} else {
+		user, err = userUsecase.RevokeRole(user.ID, role)
+	}
+	if err != nil {
+		log.Fatalf("Role change failed: %v", err)
+	}
+
+	fmt.Printf("%s now has roles %v\n", user.Email, user.RoleNames())
+}
@@ -21,4 +21,9 @@ func Execute() {
 
 func init() {
 	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(rolesCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(renormalizeCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(exportCmd)
 }
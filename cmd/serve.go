@@ -12,28 +12,37 @@ import (
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/imimran/go-grpc-auth/apperr"
+	"github.com/imimran/go-grpc-auth/auth"
 	"github.com/imimran/go-grpc-auth/config"
 	"github.com/imimran/go-grpc-auth/infrastructure"
 	pb "github.com/imimran/go-grpc-auth/proto"
+	"github.com/imimran/go-grpc-auth/ratelimit"
 	grpcDelivery "github.com/imimran/go-grpc-auth/user/delivery/grpc"
 	"github.com/imimran/go-grpc-auth/user/repository"
 	"github.com/imimran/go-grpc-auth/user/usecase"
+	"github.com/imimran/go-grpc-auth/validate"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 
 	addressHandlerPkg "github.com/imimran/go-grpc-auth/address/delivery/grpc"
-	addressRepoPkg "github.com/imimran/go-grpc-auth/address/repository"
-	addressUsecasePkg "github.com/imimran/go-grpc-auth/address/usecase"
+	addressHTTP "github.com/imimran/go-grpc-auth/address/delivery/http"
 )
 
+var noMigrate bool
+
 var serveCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Start gRPC server with grpc-gateway",
 	Run:   serve,
 }
 
+func init() {
+	serveCmd.Flags().BoolVar(&noMigrate, "no-migrate", false, "skip applying pending migrations on startup")
+}
+
 func serve(cmd *cobra.Command, args []string) {
 	// Load config
 	cfg, err := config.LoadConfig()
@@ -47,19 +56,40 @@ func serve(cmd *cobra.Command, args []string) {
 		log.Fatalf("Database connection failed: %v", err)
 	}
 
-	// Run migration
-	if err := AutoMigrate(postgresDB); err != nil {
-		log.Fatalf("Migration failed: %v", err)
+	// Apply pending migrations unless they are run as a separate deploy step
+	if !noMigrate {
+		migrator, err := migratorFor(postgresDB)
+		if err != nil {
+			log.Fatalf("Migration setup failed: %v", err)
+		}
+		if err := migrator.Up(context.Background(), 0); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+	}
+
+	// Load JWT signing / verification keys
+	keySet, err := auth.LoadKeySet(cfg.JWT)
+	if err != nil {
+		log.Fatalf("JWT key load error: %v", err)
 	}
 
 	// Setup user repository, usecase, handler
 	userRepo := repository.NewUserRepository(postgresDB)
-	userUsecase := usecase.NewUserUsecase(userRepo, []byte(cfg.JWT.Secret))
+	refreshTokenRepo := repository.NewRefreshTokenRepository(postgresDB)
+	revocationStore := usecase.NewRevocationStore(
+		repository.NewRevocationRepository(postgresDB),
+		cfg.JWT.AccessTokenTTL,
+		cfg.JWT.RevocationCacheTTL,
+	)
+	userUsecase := usecase.NewUserUsecase(userRepo, refreshTokenRepo, revocationStore, usecase.TokenOptions{
+		Signer:          keySet,
+		AccessTokenTTL:  cfg.JWT.AccessTokenTTL,
+		RefreshTokenTTL: cfg.JWT.RefreshTokenTTL,
+	}, newPasswordPolicy(cfg), newLockoutPolicy(cfg))
 	userHandler := grpcDelivery.NewUserHandler(userUsecase)
 
 	// Setup address repository, usecase, handler
-	addressRepo := addressRepoPkg.NewAddressRepo(postgresDB)
-	addressUC := addressUsecasePkg.NewAddressUsecase(addressRepo)
+	addressUC := newAddressUsecase(cfg, postgresDB)
 	addressHandler := addressHandlerPkg.NewAddressHandler(addressUC)
 
 	grpcPort := cfg.Server.GRPCPort // e.g. ":50051"
@@ -71,7 +101,33 @@ func serve(cmd *cobra.Command, args []string) {
 		log.Fatalf("Failed to listen on %s: %v", grpcPort, err)
 	}
 
-	grpcServer := grpc.NewServer()
+	// Every RPC requires a valid bearer token unless listed in jwt.public_methods,
+	// and must satisfy the module's RBAC policy
+	authInterceptor := auth.NewInterceptor(keySet, cfg.JWT.PublicMethods, revocationStore,
+		auth.MergePolicies(grpcDelivery.Policy, addressHandlerPkg.Policy))
+
+	// Domain errors become gRPC statuses in one place; it runs first so it
+	// also translates the auth interceptor's errors
+	errorInterceptor := apperr.NewInterceptor()
+	// Requests are checked against their proto field rules once authorized
+	validateInterceptor := validate.NewInterceptor()
+
+	// Calls to rate_limit.methods are limited per client IP before any
+	// other work is done for them
+	if len(cfg.RateLimit.Methods) > 0 && (cfg.RateLimit.Limit < 1 || cfg.RateLimit.Window <= 0) {
+		log.Fatalf("rate_limit.limit and rate_limit.window must be positive")
+	}
+	trustedProxies, err := ratelimit.ParseProxies(cfg.RateLimit.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Rate limit setup failed: %v", err)
+	}
+	rateLimiter := ratelimit.NewSlidingWindow(cfg.RateLimit.Limit, cfg.RateLimit.Window)
+	rateLimitInterceptor := ratelimit.NewInterceptor(rateLimiter, cfg.RateLimit.Methods, trustedProxies)
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(errorInterceptor.Unary(), rateLimitInterceptor.Unary(), authInterceptor.Unary(), validateInterceptor.Unary()),
+		grpc.ChainStreamInterceptor(errorInterceptor.Stream(), rateLimitInterceptor.Stream(), authInterceptor.Stream(), validateInterceptor.Stream()),
+	)
 	pb.RegisterUserServiceServer(grpcServer, userHandler)
 	pb.RegisterAddressServiceServer(grpcServer, addressHandler)
 	reflection.Register(grpcServer) // optional
@@ -92,7 +148,12 @@ func serve(cmd *cobra.Command, args []string) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	mux := runtime.NewServeMux()
+	go revocationStore.StartCleanup(ctx, cfg.JWT.RevocationCleanupInterval)
+	if len(cfg.RateLimit.Methods) > 0 {
+		go rateLimiter.StartCleanup(ctx, cfg.RateLimit.Window)
+	}
+
+	mux := runtime.NewServeMux(runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher))
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
 	grpcEndpoint := grpcPort
@@ -100,13 +161,30 @@ func serve(cmd *cobra.Command, args []string) {
 		grpcEndpoint = "localhost" + grpcEndpoint
 	}
 
-	if err := pb.RegisterUserServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, opts); err != nil {
+	if err := registerGateway(ctx, mux, grpcServer, grpcEndpoint, opts); err != nil {
 		log.Fatalf("Failed to start HTTP gateway: %v", err)
 	}
 
+	// JWKS is served next to the gateway routes
+	httpMux := http.NewServeMux()
+	httpMux.Handle(auth.JWKSPath, keySet.JWKSHandler())
+	log.Printf("HTTP route %-6s %s", "GET", auth.JWKSPath)
+
+	// Address export is a file download rather than a JSON stream, so it is
+	// served beside the gateway through its own client connection
+	exportConn, err := grpc.NewClient(grpcEndpoint, opts...)
+	if err != nil {
+		log.Fatalf("Failed to dial gRPC server for export: %v", err)
+	}
+	defer exportConn.Close()
+	httpMux.Handle("GET "+addressHTTP.ExportPath, addressHTTP.NewExportHandler(pb.NewAddressServiceClient(exportConn)))
+	log.Printf("HTTP route %-6s %s", "GET", addressHTTP.ExportPath)
+
+	httpMux.Handle("/", mux)
+
 	httpServer := &http.Server{
 		Addr:    httpPort,
-		Handler: mux,
+		Handler: httpMux,
 	}
 
 	// Run HTTP server goroutine
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/imimran/go-grpc-auth/config"
+	"github.com/imimran/go-grpc-auth/user/breached"
+	"github.com/imimran/go-grpc-auth/user/domain"
+	"github.com/imimran/go-grpc-auth/user/hasher"
+)
+
+// newPasswordPolicy builds the password policy from config, exiting when the
+// hasher settings are invalid or the breached password list cannot be opened.
+func newPasswordPolicy(cfg *config.Config) domain.PasswordPolicy {
+	passwordHasher, err := hasher.New(cfg.Password.Hash)
+	if err != nil {
+		log.Fatalf("Password hasher setup failed: %v", err)
+	}
+
+	policy := domain.PasswordPolicy{
+		Hasher:         passwordHasher,
+		MinLength:      cfg.Password.MinLength,
+		MaxLength:      cfg.Password.MaxLength,
+		MinCharClasses: cfg.Password.MinCharClasses,
+	}
+	if cfg.Password.BreachedListDir != "" {
+		list, err := breached.New(cfg.Password.BreachedListDir, cfg.Password.BreachedMinCount)
+		if err != nil {
+			log.Fatalf("Password policy setup failed: %v", err)
+		}
+		policy.Breached = list
+	}
+	return policy
+}
+
+func newLockoutPolicy(cfg *config.Config) domain.LockoutPolicy {
+	return domain.LockoutPolicy{
+		FreeAttempts: cfg.Lockout.FreeAttempts,
+		BaseDelay:    cfg.Lockout.BaseDelay,
+		MaxDelay:     cfg.Lockout.MaxDelay,
+		Threshold:    cfg.Lockout.Threshold,
+		Duration:     cfg.Lockout.Duration,
+	}
+}
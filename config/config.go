@@ -2,14 +2,20 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Database DatabaseConfig `mapstructure:"database"`
-	Server   ServerConfig   `mapstructure:"server"`
-	JWT      JWTConfig      `mapstructure:"jwt"`
+	Database      DatabaseConfig      `mapstructure:"database"`
+	Server        ServerConfig        `mapstructure:"server"`
+	JWT           JWTConfig           `mapstructure:"jwt"`
+	Geocoding     GeocodingConfig     `mapstructure:"geocoding"`
+	Normalization NormalizationConfig `mapstructure:"normalization"`
+	Password      PasswordConfig      `mapstructure:"password"`
+	Lockout       LockoutConfig       `mapstructure:"lockout"`
+	RateLimit     RateLimitConfig     `mapstructure:"rate_limit"`
 }
 
 type DatabaseConfig struct {
@@ -23,12 +29,103 @@ type DatabaseConfig struct {
 }
 
 type ServerConfig struct {
-	GRPCPort string `mapstructure:"grpc_port"`       // ":50051"
-    HTTPPort string `mapstructure:"http_port"`
+	GRPCPort string `mapstructure:"grpc_port"` // ":50051"
+	HTTPPort string `mapstructure:"http_port"`
 }
 
 type JWTConfig struct {
-	Secret string `mapstructure:"secret"`
+	Secret          string         `mapstructure:"secret"`         // HS256 fallback when no keys are configured
+	SigningKeyID    string         `mapstructure:"signing_key_id"` // kid of the key used to sign new tokens
+	Keys            []JWTKeyConfig `mapstructure:"keys"`
+	AccessTokenTTL  time.Duration  `mapstructure:"access_token_ttl"`  // e.g. "15m"
+	RefreshTokenTTL time.Duration  `mapstructure:"refresh_token_ttl"` // e.g. "720h"
+	PublicMethods   []string       `mapstructure:"public_methods"`    // full gRPC method names that skip auth
+
+	RevocationCacheTTL        time.Duration `mapstructure:"revocation_cache_ttl"`        // how long "not revoked" answers are cached
+	RevocationCleanupInterval time.Duration `mapstructure:"revocation_cleanup_interval"` // purge of expired revocations
+}
+
+// JWTKeyConfig points at an RSA or Ed25519 key in PEM format. Keys that are
+// only kept for verifying older tokens need just the public key.
+type JWTKeyConfig struct {
+	ID             string `mapstructure:"id"`
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+	PublicKeyFile  string `mapstructure:"public_key_file"`
+}
+
+// GeocodingConfig lists the providers tried, in order, when an address is
+// created without coordinates. An empty list disables geocoding.
+type GeocodingConfig struct {
+	Providers []GeocoderProviderConfig `mapstructure:"providers"`
+
+	// ReverseToleranceMeters is how far ReverseGeocode looks for a stored
+	// address before asking the providers
+	ReverseToleranceMeters float64 `mapstructure:"reverse_tolerance_meters"`
+
+	// ImportRate caps geocoder lookups per second while importing files
+	// with geocoding enabled; 1 by default
+	ImportRate float64 `mapstructure:"import_rate"`
+}
+
+type GeocoderProviderConfig struct {
+	Type      string        `mapstructure:"type"` // "nominatim" or "gazetteer"
+	Name      string        `mapstructure:"name"` // recorded on geocoded addresses; defaults to the type
+	URL       string        `mapstructure:"url"`  // nominatim: base URL
+	UserAgent string        `mapstructure:"user_agent"`
+	Email     string        `mapstructure:"email"`
+	Timeout   time.Duration `mapstructure:"timeout"`
+	File      string        `mapstructure:"file"` // gazetteer: CSV path
+}
+
+// NormalizationConfig selects the rule table used to normalize addresses
+type NormalizationConfig struct {
+	Locale string `mapstructure:"locale"` // "en", "de" or "fr"
+}
+
+// PasswordConfig is the policy new passwords must satisfy. Existing
+// passwords are not re-checked.
+type PasswordConfig struct {
+	MinLength      int `mapstructure:"min_length"`       // in characters
+	MaxLength      int `mapstructure:"max_length"`       // in bytes, at most 72 (bcrypt's limit)
+	MinCharClasses int `mapstructure:"min_char_classes"` // of lower case, upper case, digits, symbols
+
+	// BreachedListDir holds HIBP range files; empty disables the breach check
+	BreachedListDir  string `mapstructure:"breached_list_dir"`
+	BreachedMinCount int    `mapstructure:"breached_min_count"` // breaches needed to reject a password
+
+	Hash PasswordHashConfig `mapstructure:"hash"`
+}
+
+// PasswordHashConfig selects how new passwords are stored. Hashes made with
+// the other algorithm or other costs still verify and are upgraded on login.
+type PasswordHashConfig struct {
+	Algorithm         string `mapstructure:"algorithm"`     // "argon2id" or "bcrypt"
+	Argon2Memory      uint32 `mapstructure:"argon2_memory"` // KiB
+	Argon2Iterations  uint32 `mapstructure:"argon2_iterations"`
+	Argon2Parallelism uint8  `mapstructure:"argon2_parallelism"`
+	BcryptCost        int    `mapstructure:"bcrypt_cost"`
+}
+
+// LockoutConfig slows password guessing per account: after free_attempts
+// consecutive failed logins each further one locks the account for an
+// exponentially growing delay, and from threshold failures on for duration.
+type LockoutConfig struct {
+	FreeAttempts int           `mapstructure:"free_attempts"`
+	BaseDelay    time.Duration `mapstructure:"base_delay"` // e.g. "1s", doubled per failure
+	MaxDelay     time.Duration `mapstructure:"max_delay"`
+	Threshold    int           `mapstructure:"threshold"` // 0 disables the temporary lockout
+	Duration     time.Duration `mapstructure:"duration"`  // e.g. "15m"
+}
+
+// RateLimitConfig limits calls to the listed RPCs per client IP within a
+// sliding window.
+type RateLimitConfig struct {
+	Methods []string      `mapstructure:"methods"` // full gRPC method names; empty disables the limit
+	Limit   int           `mapstructure:"limit"`   // calls per window and IP
+	Window  time.Duration `mapstructure:"window"`
+	// TrustedProxies are CIDRs or addresses whose X-Forwarded-For is
+	// believed; the in-process gateway connects over loopback
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -39,6 +136,8 @@ func LoadConfig() (*Config, error) {
 	// Allow override with ENV variables
 	viper.AutomaticEnv()
 
+	setDefaults()
+
 	err := viper.ReadInConfig()
 	if err != nil {
 		log.Printf("Error reading config file: %v", err)
@@ -55,3 +154,31 @@ func LoadConfig() (*Config, error) {
 	log.Println("Config loaded successfully")
 	return &cfg, nil
 }
+
+// setDefaults keeps the server usable when optional keys are missing from config.yaml.
+func setDefaults() {
+	viper.SetDefault("jwt.access_token_ttl", 15*time.Minute)
+	viper.SetDefault("jwt.refresh_token_ttl", 30*24*time.Hour)
+	viper.SetDefault("jwt.revocation_cache_ttl", 30*time.Second)
+	viper.SetDefault("jwt.revocation_cleanup_interval", 10*time.Minute)
+	viper.SetDefault("geocoding.reverse_tolerance_meters", 50)
+	viper.SetDefault("normalization.locale", "en")
+	viper.SetDefault("password.min_length", 8)
+	viper.SetDefault("password.max_length", 72)
+	viper.SetDefault("password.min_char_classes", 2)
+	viper.SetDefault("password.breached_min_count", 1)
+	viper.SetDefault("password.hash.algorithm", "argon2id")
+	viper.SetDefault("password.hash.argon2_memory", 19*1024)
+	viper.SetDefault("password.hash.argon2_iterations", 2)
+	viper.SetDefault("password.hash.argon2_parallelism", 1)
+	viper.SetDefault("password.hash.bcrypt_cost", 10)
+	viper.SetDefault("lockout.free_attempts", 3)
+	viper.SetDefault("lockout.base_delay", time.Second)
+	viper.SetDefault("lockout.max_delay", 5*time.Minute)
+	viper.SetDefault("lockout.threshold", 10)
+	viper.SetDefault("lockout.duration", 15*time.Minute)
+	viper.SetDefault("rate_limit.methods", []string{"/pb.UserService/Login"})
+	viper.SetDefault("rate_limit.limit", 20)
+	viper.SetDefault("rate_limit.window", time.Minute)
+	viper.SetDefault("rate_limit.trusted_proxies", []string{"127.0.0.1/32", "::1/128"})
+}
@@ -0,0 +1,399 @@
+// Package migrate applies the versioned SQL migrations embedded in the
+// migrations package and records them in the schema_migrations table.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lockKey identifies this service's migrations in pg_advisory_lock so that
+// several pods starting at once apply them one at a time.
+const lockKey int64 = 0x67727063617574 // "grpcaut"
+
+var (
+	fileName    = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+	namePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Status describes one migration as seen by the database.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+	Dirty     bool
+	// Modified is true when the file changed after it was applied
+	Modified bool
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+type appliedRow struct {
+	checksum  string
+	appliedAt time.Time
+	dirty     bool
+}
+
+// New loads and validates every migration in fsys.
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		m := fileName.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", mig.Version, mig.Name)
+		}
+		sum := sha256.Sum256([]byte(mig.Up + "\x00" + mig.Down))
+		mig.Checksum = hex.EncodeToString(sum[:])
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up applies pending migrations in order. steps <= 0 applies all of them.
+func (m *Migrator) Up(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(conn *sql.Conn, applied map[int64]appliedRow) error {
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+
+		done := 0
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if steps > 0 && done == steps {
+				break
+			}
+			if err := m.run(ctx, conn, mig, mig.Up, true); err != nil {
+				return err
+			}
+			log.Printf("Applied migration %d_%s", mig.Version, mig.Name)
+			done++
+		}
+
+		if done == 0 {
+			log.Println("Database schema is up to date")
+		}
+		return nil
+	})
+}
+
+// Down rolls back the most recently applied migrations. steps <= 0 rolls back one.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		steps = 1
+	}
+	return m.withLock(ctx, func(conn *sql.Conn, applied map[int64]appliedRow) error {
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+
+		done := 0
+		for i := len(m.migrations) - 1; i >= 0 && done < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if mig.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", mig.Version, mig.Name)
+			}
+			if err := m.run(ctx, conn, mig, mig.Down, false); err != nil {
+				return err
+			}
+			log.Printf("Rolled back migration %d_%s", mig.Version, mig.Name)
+			done++
+		}
+		return nil
+	})
+}
+
+// Status lists every known migration with its applied state.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]appliedRow) error {
+		for _, mig := range m.migrations {
+			st := Status{Migration: mig}
+			if row, ok := applied[mig.Version]; ok {
+				st.Applied = true
+				st.AppliedAt = row.appliedAt
+				st.Dirty = row.dirty
+				st.Modified = row.checksum != mig.Checksum
+			}
+			statuses = append(statuses, st)
+		}
+		for version, row := range applied {
+			if !m.known(version) {
+				statuses = append(statuses, Status{
+					Migration: Migration{Version: version, Name: "<missing file>"},
+					Applied:   true, AppliedAt: row.appliedAt, Dirty: row.dirty,
+				})
+			}
+		}
+		sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+		return nil
+	})
+	return statuses, err
+}
+
+// Force records the schema as being exactly at version without running any
+// SQL: migrations up to version are marked applied (clean, with their
+// current checksum) and later records are removed. Use it to recover from a
+// dirty state or to baseline a database created before migrations existed.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if version != 0 && !m.known(version) {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn, _ map[int64]appliedRow) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if mig.Version > version {
+				break
+			}
+			if err := insertApplied(ctx, tx, mig, false); err != nil {
+				return err
+			}
+		}
+		return tx.Commit()
+	})
+}
+
+// Create writes an empty up/down pair for the next version into dir.
+func Create(dir, name string) ([]string, error) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), "_"))
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid migration name %q: use letters, digits and underscores", name)
+	}
+
+	existing, err := load(os.DirFS(dir))
+	if err != nil {
+		return nil, err
+	}
+	next := int64(1)
+	if n := len(existing); n > 0 {
+		next = existing[n-1].Version + 1
+	}
+
+	var paths []string
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(dir, fmt.Sprintf("%04d_%s.%s.sql", next, name, direction))
+		body := fmt.Sprintf("-- %04d_%s (%s)\n", next, name, direction)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// run executes one migration body inside a transaction. The version is
+// first recorded as dirty outside the transaction, so a crash mid-way
+// leaves a marker that blocks further runs until `migrate force`.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, mig Migration, body string, up bool) error {
+	if up {
+		if err := insertApplied(ctx, conn, mig, true); err != nil {
+			return err
+		}
+	} else if _, err := conn.ExecContext(ctx, `UPDATE schema_migrations SET dirty = TRUE WHERE version = $1`, mig.Version); err != nil {
+		return err
+	}
+
+	err := func() error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if _, err := tx.ExecContext(ctx, body); err != nil {
+			return err
+		}
+		if up {
+			_, err = tx.ExecContext(ctx, `UPDATE schema_migrations SET dirty = FALSE WHERE version = $1`, mig.Version)
+		} else {
+			_, err = tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Commit()
+	}()
+	if err == nil {
+		return nil
+	}
+
+	// The transaction rolled back cleanly, so the schema is unchanged:
+	// restore the bookkeeping instead of leaving the version dirty.
+	if up {
+		conn.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+	} else {
+		conn.ExecContext(ctx, `UPDATE schema_migrations SET dirty = FALSE WHERE version = $1`, mig.Version)
+	}
+	return fmt.Errorf("migration %d_%s failed: %w", mig.Version, mig.Name, err)
+}
+
+// verify refuses to proceed on a dirty or tampered schema.
+func (m *Migrator) verify(applied map[int64]appliedRow) error {
+	for _, mig := range m.migrations {
+		row, ok := applied[mig.Version]
+		if !ok {
+			continue
+		}
+		if row.dirty {
+			return fmt.Errorf("migration %d_%s is dirty; fix the schema manually and run `migrate force <version>`", mig.Version, mig.Name)
+		}
+		if row.checksum != mig.Checksum {
+			return fmt.Errorf("migration %d_%s was modified after being applied (checksum mismatch)", mig.Version, mig.Name)
+		}
+	}
+	for version := range applied {
+		if !m.known(version) {
+			return fmt.Errorf("database has migration %d applied but its file is missing", version)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) known(version int64) bool {
+	for _, mig := range m.migrations {
+		if mig.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// withLock runs fn on a dedicated connection holding the advisory lock,
+// after making sure schema_migrations exists.
+func (m *Migrator) withLock(ctx context.Context, fn func(*sql.Conn, map[int64]appliedRow) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey)
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    BIGINT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		checksum   TEXT NOT NULL,
+		dirty      BOOLEAN NOT NULL DEFAULT FALSE,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return err
+	}
+
+	applied, err := readApplied(ctx, conn)
+	if err != nil {
+		return err
+	}
+	return fn(conn, applied)
+}
+
+func readApplied(ctx context.Context, conn *sql.Conn) (map[int64]appliedRow, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, checksum, applied_at, dirty FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int64]appliedRow{}
+	for rows.Next() {
+		var version int64
+		var row appliedRow
+		if err := rows.Scan(&version, &row.checksum, &row.appliedAt, &row.dirty); err != nil {
+			return nil, err
+		}
+		applied[version] = row
+	}
+	return applied, rows.Err()
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func insertApplied(ctx context.Context, db execer, mig Migration, dirty bool) error {
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name, checksum, dirty) VALUES ($1, $2, $3, $4)`,
+		mig.Version, mig.Name, mig.Checksum, dirty)
+	if err != nil {
+		return fmt.Errorf("record migration %d: %w", mig.Version, err)
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS addresses;
+DROP TABLE IF EXISTS session_revocations;
+DROP TABLE IF EXISTS revoked_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,61 @@
+-- Required for the geography column on addresses
+CREATE EXTENSION IF NOT EXISTS postgis;
+
+CREATE TABLE users (
+    id        BIGSERIAL PRIMARY KEY,
+    email     VARCHAR(255) NOT NULL,
+    password  TEXT NOT NULL,
+    full_name VARCHAR(255),
+    CONSTRAINT uni_users_email UNIQUE (email)
+);
+
+CREATE TABLE user_roles (
+    user_id    BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role       VARCHAR(32) NOT NULL,
+    created_at TIMESTAMPTZ,
+    PRIMARY KEY (user_id, role)
+);
+
+CREATE TABLE refresh_tokens (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    family_id  UUID NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE UNIQUE INDEX idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+
+CREATE TABLE revoked_tokens (
+    jti        VARCHAR(64) PRIMARY KEY,
+    user_id    BIGINT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX idx_revoked_tokens_user_id ON revoked_tokens (user_id);
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens (expires_at);
+
+CREATE TABLE session_revocations (
+    user_id    BIGINT PRIMARY KEY,
+    revoked_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_session_revocations_expires_at ON session_revocations (expires_at);
+
+CREATE TABLE addresses (
+    id                 UUID PRIMARY KEY,
+    user_id            BIGINT CONSTRAINT fk_addresses_user REFERENCES users (id) ON DELETE CASCADE,
+    raw_address        TEXT,
+    normalized_address TEXT,
+    latitude           DOUBLE PRECISION,
+    longitude          DOUBLE PRECISION,
+    accuracy           TEXT,
+    source             TEXT,
+    geom               GEOGRAPHY(Point, 4326)
+);
+CREATE INDEX idx_addresses_user_id ON addresses (user_id);
+CREATE UNIQUE INDEX idx_addresses_normalized_address ON addresses (normalized_address);
@@ -0,0 +1,2 @@
+-- Spatial index used by ST_DWithin / distance ordering in address searches
+CREATE INDEX idx_addresses_geom ON addresses USING GIST (geom);
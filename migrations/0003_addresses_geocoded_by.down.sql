@@ -0,0 +1 @@
+ALTER TABLE addresses DROP COLUMN IF EXISTS geocoded_by;
@@ -0,0 +1,2 @@
+-- Which geocoding provider resolved the coordinates; NULL when the client sent them
+ALTER TABLE addresses ADD COLUMN geocoded_by TEXT;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_addresses_normalizer_version;
+ALTER TABLE addresses DROP COLUMN IF EXISTS normalizer_version;
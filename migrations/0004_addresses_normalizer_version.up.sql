@@ -0,0 +1,4 @@
+-- Version of the normalization rules that produced normalized_address.
+-- Existing rows were lowercased and trimmed only, which is version 0.
+ALTER TABLE addresses ADD COLUMN normalizer_version INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX idx_addresses_normalizer_version ON addresses (normalizer_version);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_addresses_country_code;
+DROP INDEX IF EXISTS idx_addresses_postal_code;
+DROP INDEX IF EXISTS idx_addresses_locality;
+
+ALTER TABLE addresses
+    DROP COLUMN IF EXISTS country_code,
+    DROP COLUMN IF EXISTS postal_code,
+    DROP COLUMN IF EXISTS region,
+    DROP COLUMN IF EXISTS locality,
+    DROP COLUMN IF EXISTS unit,
+    DROP COLUMN IF EXISTS street,
+    DROP COLUMN IF EXISTS house_number;
@@ -0,0 +1,14 @@
+-- Structured address components, parsed from raw_address when not supplied
+ALTER TABLE addresses
+    ADD COLUMN house_number TEXT,
+    ADD COLUMN street       TEXT,
+    ADD COLUMN unit         TEXT,
+    ADD COLUMN locality     TEXT,
+    ADD COLUMN region       TEXT,
+    ADD COLUMN postal_code  TEXT,
+    ADD COLUMN country_code VARCHAR(2);
+
+-- ListAddress filters compare lower(column)
+CREATE INDEX idx_addresses_locality ON addresses (lower(locality));
+CREATE INDEX idx_addresses_postal_code ON addresses (lower(postal_code));
+CREATE INDEX idx_addresses_country_code ON addresses (lower(country_code));
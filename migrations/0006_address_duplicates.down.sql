@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS address_merges;
+DROP INDEX IF EXISTS idx_addresses_normalized_address_trgm;
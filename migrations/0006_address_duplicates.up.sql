@@ -0,0 +1,17 @@
+-- Trigram similarity for fuzzy duplicate detection
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX idx_addresses_normalized_address_trgm ON addresses USING GIN (normalized_address gin_trgm_ops);
+
+-- One row per address folded into a survivor by MergeAddresses. Merged ids
+-- keep resolving to the survivor through this table.
+CREATE TABLE address_merges (
+    id             BIGSERIAL PRIMARY KEY,
+    survivor_id    UUID NOT NULL REFERENCES addresses (id) ON DELETE CASCADE,
+    merged_id      UUID NOT NULL,
+    merged_user_id BIGINT,
+    snapshot       JSONB,
+    merged_by      BIGINT,
+    created_at     TIMESTAMPTZ
+);
+CREATE INDEX idx_address_merges_survivor_id ON address_merges (survivor_id);
+CREATE UNIQUE INDEX idx_address_merges_merged_id ON address_merges (merged_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_addresses_user_id_created_at_id;
+DROP INDEX IF EXISTS idx_addresses_created_at_id;
+DROP INDEX IF EXISTS idx_users_created_at_id;
+ALTER TABLE addresses DROP COLUMN IF EXISTS created_at;
+ALTER TABLE users DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,9 @@
+-- Creation time for stable list ordering and keyset pagination. Existing rows
+-- all get the migration time, so among them the id decides the order.
+ALTER TABLE users ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE addresses ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- Lists are read newest first as (created_at, id) < cursor
+CREATE INDEX idx_users_created_at_id ON users (created_at, id);
+CREATE INDEX idx_addresses_created_at_id ON addresses (created_at, id);
+CREATE INDEX idx_addresses_user_id_created_at_id ON addresses (user_id, created_at, id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_search_trgm;
+DROP INDEX IF EXISTS idx_users_full_name_trgm;
+DROP INDEX IF EXISTS idx_users_email_prefix;
+ALTER TABLE users DROP COLUMN IF EXISTS status;
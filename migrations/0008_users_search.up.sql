@@ -0,0 +1,10 @@
+-- Account status; disabled users cannot log in
+ALTER TABLE users ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'active'
+    CONSTRAINT chk_users_status CHECK (status IN ('active', 'disabled'));
+
+-- ListUsers filters: email prefix (LIKE 'x%'), name substring and free text
+-- (ILIKE '%x%'). The expressions must match the repository's exactly.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX idx_users_email_prefix ON users (lower(email) text_pattern_ops);
+CREATE INDEX idx_users_full_name_trgm ON users USING GIN (full_name gin_trgm_ops);
+CREATE INDEX idx_users_search_trgm ON users USING GIN ((email || ' ' || COALESCE(full_name, '')) gin_trgm_ops);
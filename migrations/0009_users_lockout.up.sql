@@ -0,0 +1,4 @@
+-- Login lockout: consecutive failed logins and the time logins are refused
+-- until. Both are reset by a successful login or an admin unlock.
+ALTER TABLE users ADD COLUMN failed_logins INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN locked_until TIMESTAMPTZ;
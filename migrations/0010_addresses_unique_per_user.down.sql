@@ -0,0 +1,3 @@
+-- Fails while two users share an address; resolve those rows first
+CREATE UNIQUE INDEX idx_addresses_normalized_address ON addresses (normalized_address);
+DROP INDEX IF EXISTS idx_addresses_user_normalized_address;
@@ -0,0 +1,4 @@
+-- Addresses are unique per owner, not globally: two users may store the
+-- same address, and a collision never reveals another user's data.
+CREATE UNIQUE INDEX idx_addresses_user_normalized_address ON addresses (user_id, normalized_address);
+DROP INDEX IF EXISTS idx_addresses_normalized_address;
@@ -0,0 +1 @@
+ALTER TABLE addresses DROP COLUMN IF EXISTS normalizer_locale;
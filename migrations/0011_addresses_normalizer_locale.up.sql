@@ -0,0 +1,5 @@
+-- Locale whose rule table produced normalized_address, so changing
+-- normalization.locale marks rows stale like a version bump does. The
+-- locale of existing rows is unknown; '' makes the next renormalize run
+-- rewrite them once.
+ALTER TABLE addresses ADD COLUMN normalizer_locale VARCHAR(8) NOT NULL DEFAULT '';
@@ -0,0 +1,12 @@
+// Package migrations embeds the versioned SQL schema migrations.
+//
+// Files are named <version>_<name>.up.sql / <version>_<name>.down.sql and are
+// applied in version order by `go-grpc-auth migrate up` (and on serve startup).
+// Never edit a migration that has been applied anywhere; add a new one with
+// `go-grpc-auth migrate create <name>`.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,118 @@
+// Package pagination implements the opaque page tokens used by the list RPCs.
+// A token is the (created_at, id) of the last row served; the next page
+// starts strictly after it, so inserts and deletes between requests never
+// shift or repeat rows the way OFFSET does.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/imimran/go-grpc-auth/apperr"
+)
+
+var ErrInvalidPageToken = apperr.InvalidArgument("INVALID_PAGE_TOKEN", "invalid page_token").
+	WithField("page_token", "not a token returned by this list")
+
+// Defaults shared by the list RPCs
+const (
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+// Cursor is the position of a row in created_at DESC, id DESC order, or in
+// the order named by Order when a list can be sorted another way.
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"i"`
+	// Key is the row's sort value when Order sorts by something other than
+	// created_at
+	Key   string `json:"k,omitempty"`
+	Order string `json:"o,omitempty"`
+}
+
+// Encode returns the cursor as an opaque, URL-safe page token.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode parses a page token. An empty token is the first page and returns
+// nil.
+func Decode(token string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" || c.CreatedAt.IsZero() {
+		return nil, ErrInvalidPageToken
+	}
+	return &c, nil
+}
+
+// Limit applies DefaultLimit and MaxLimit.
+func Limit(limit int) int {
+	if limit <= 0 {
+		return DefaultLimit
+	}
+	if limit > MaxLimit {
+		return MaxLimit
+	}
+	return limit
+}
+
+// Query selects one page of a list. With a Token the page starts after that
+// cursor; without one Page and Limit select it by offset, as before cursors.
+type Query struct {
+	Page  int // 1-based, offset mode only
+	Limit int
+	Token string
+	// IncludeTotal asks for a COUNT(*) of all matching rows. Unset counts in
+	// offset mode only, which is what offset clients have always received.
+	IncludeTotal *bool
+}
+
+// Keyset reports whether the query continues from a page token.
+func (q Query) Keyset() bool {
+	return q.Token != ""
+}
+
+// CountTotal reports whether the total should be counted.
+func (q Query) CountTotal() bool {
+	if q.IncludeTotal != nil {
+		return *q.IncludeTotal
+	}
+	return !q.Keyset()
+}
+
+// Offset is the number of rows to skip in offset mode.
+func (q Query) Offset() int {
+	if q.Keyset() || q.Page <= 1 {
+		return 0
+	}
+	return (q.Page - 1) * Limit(q.Limit)
+}
+
+// Page is one page of results. Total is only meaningful when Counted.
+type Page[T any] struct {
+	Items         []T
+	Total         int64
+	Counted       bool
+	Page          int // offset mode only
+	NextPageToken string
+}
+
+// Trim cuts rows, fetched with limit+1 to detect a following page, down to
+// limit and returns the token for the next page, or "" on the last one.
+func Trim[T any](rows []T, limit int, cursor func(T) Cursor) ([]T, string) {
+	if len(rows) <= limit {
+		return rows, ""
+	}
+	rows = rows[:limit]
+	return rows, cursor(rows[len(rows)-1]).Encode()
+}
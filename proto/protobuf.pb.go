@@ -12,6 +12,8 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -201,10 +203,15 @@ func (x *AddressId) GetId() string {
 }
 
 type User struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	FullName      string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email     string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	FullName  string                 `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Roles     []string               `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	Status    string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // active or disabled
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Set while logins are refused after repeated failures; see UnlockUser
+	LockedUntil   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=locked_until,json=lockedUntil,proto3" json:"locked_until,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -260,6 +267,34 @@ func (x *User) GetFullName() string {
 	return ""
 }
 
+func (x *User) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *User) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetLockedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LockedUntil
+	}
+	return nil
+}
+
 type CreateUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
@@ -321,13 +356,20 @@ func (x *CreateUserRequest) GetFullName() string {
 }
 
 type UpdateUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
-	FullName      string                 `protobuf:"bytes,4,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email    string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Password string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	FullName string                 `protobuf:"bytes,4,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Status   string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // active or disabled; admins only
+	// Fields to change: email, password, full_name, status. Listed fields are
+	// set even when empty, so full_name can be cleared. Without a mask every
+	// non-empty field is changed.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Required when users change their own email or password
+	CurrentPassword string `protobuf:"bytes,7,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateUserRequest) Reset() {
@@ -388,6 +430,27 @@ func (x *UpdateUserRequest) GetFullName() string {
 	return ""
 }
 
+func (x *UpdateUserRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
 type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
@@ -442,7 +505,10 @@ func (x *LoginRequest) GetPassword() string {
 
 type LoginResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                   // short-lived access token (JWT)
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // opaque, single-use; exchange via RefreshToken
+	ExpiresIn     int64                  `protobuf:"varint,3,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`         // access token lifetime in seconds
+	TokenType     string                 `protobuf:"bytes,4,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`          // always "Bearer"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -484,27 +550,48 @@ func (x *LoginResponse) GetToken() string {
 	return ""
 }
 
-type UserListResponse struct {
+func (x *LoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *LoginResponse) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+type RefreshTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserListResponse) Reset() {
-	*x = UserListResponse{}
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
 	mi := &file_proto_protobuf_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserListResponse) String() string {
+func (x *RefreshTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserListResponse) ProtoMessage() {}
+func (*RefreshTokenRequest) ProtoMessage() {}
 
-func (x *UserListResponse) ProtoReflect() protoreflect.Message {
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_protobuf_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -516,44 +603,40 @@ func (x *UserListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserListResponse.ProtoReflect.Descriptor instead.
-func (*UserListResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
 	return file_proto_protobuf_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *UserListResponse) GetUsers() []*User {
+func (x *RefreshTokenRequest) GetRefreshToken() string {
 	if x != nil {
-		return x.Users
+		return x.RefreshToken
 	}
-	return nil
+	return ""
 }
 
-type Address struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	RawAddress        string                 `protobuf:"bytes,2,opt,name=raw_address,json=rawAddress,proto3" json:"raw_address,omitempty"`
-	NormalizedAddress string                 `protobuf:"bytes,3,opt,name=normalized_address,json=normalizedAddress,proto3" json:"normalized_address,omitempty"`
-	Coordinates       *Coordinates           `protobuf:"bytes,4,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
-	Accuracy          string                 `protobuf:"bytes,5,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
-	Source            string                 `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type RoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"` // e.g. "admin"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Address) Reset() {
-	*x = Address{}
+func (x *RoleRequest) Reset() {
+	*x = RoleRequest{}
 	mi := &file_proto_protobuf_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Address) String() string {
+func (x *RoleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Address) ProtoMessage() {}
+func (*RoleRequest) ProtoMessage() {}
 
-func (x *Address) ProtoReflect() protoreflect.Message {
+func (x *RoleRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_protobuf_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -565,79 +648,107 @@ func (x *Address) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Address.ProtoReflect.Descriptor instead.
-func (*Address) Descriptor() ([]byte, []int) {
+// Deprecated: Use RoleRequest.ProtoReflect.Descriptor instead.
+func (*RoleRequest) Descriptor() ([]byte, []int) {
 	return file_proto_protobuf_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *Address) GetId() string {
+func (x *RoleRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-func (x *Address) GetRawAddress() string {
+func (x *RoleRequest) GetRole() string {
 	if x != nil {
-		return x.RawAddress
+		return x.Role
 	}
 	return ""
 }
 
-func (x *Address) GetNormalizedAddress() string {
-	if x != nil {
-		return x.NormalizedAddress
-	}
-	return ""
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // optional: also revokes this refresh token's session
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Address) GetCoordinates() *Coordinates {
-	if x != nil {
-		return x.Coordinates
-	}
-	return nil
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Address) GetAccuracy() string {
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[11]
 	if x != nil {
-		return x.Accuracy
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Address) GetSource() string {
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LogoutRequest) GetRefreshToken() string {
 	if x != nil {
-		return x.Source
+		return x.RefreshToken
 	}
 	return ""
 }
 
-type CreateAddressRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	RawAddress    string                 `protobuf:"bytes,2,opt,name=raw_address,json=rawAddress,proto3" json:"raw_address,omitempty"`
-	Coordinates   *Coordinates           `protobuf:"bytes,3,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
-	Accuracy      string                 `protobuf:"bytes,4,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
-	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+type ListUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`   // offset mode, 1-based
+	Limit int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // default 10, max 100
+	// Cursor mode: next_page_token of the previous page; page is then ignored
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Count all users into total. Unset counts in offset mode only.
+	IncludeTotal *bool `protobuf:"varint,4,opt,name=include_total,json=includeTotal,proto3,oneof" json:"include_total,omitempty"`
+	// Filters, all optional and combined with AND
+	EmailPrefix   string                 `protobuf:"bytes,5,opt,name=email_prefix,json=emailPrefix,proto3" json:"email_prefix,omitempty"`    // case-insensitive
+	NameContains  string                 `protobuf:"bytes,6,opt,name=name_contains,json=nameContains,proto3" json:"name_contains,omitempty"` // case-insensitive
+	Role          string                 `protobuf:"bytes,7,opt,name=role,proto3" json:"role,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`                                     // active or disabled
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`     // inclusive
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"` // exclusive
+	Q             string                 `protobuf:"bytes,11,opt,name=q,proto3" json:"q,omitempty"`                                              // free text; every word must appear in the email or name
+	// created_at (default, newest first), email or full_name, optionally
+	// followed by asc or desc
+	OrderBy       string `protobuf:"bytes,12,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAddressRequest) Reset() {
-	*x = CreateAddressRequest{}
-	mi := &file_proto_protobuf_proto_msgTypes[11]
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAddressRequest) String() string {
+func (x *ListUsersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAddressRequest) ProtoMessage() {}
+func (*ListUsersRequest) ProtoMessage() {}
 
-func (x *CreateAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_protobuf_proto_msgTypes[11]
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,144 +759,119 @@ func (x *CreateAddressRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAddressRequest.ProtoReflect.Descriptor instead.
-func (*CreateAddressRequest) Descriptor() ([]byte, []int) {
-	return file_proto_protobuf_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *CreateAddressRequest) GetUserId() int64 {
+func (x *ListUsersRequest) GetPage() int32 {
 	if x != nil {
-		return x.UserId
+		return x.Page
 	}
 	return 0
 }
 
-func (x *CreateAddressRequest) GetRawAddress() string {
+func (x *ListUsersRequest) GetLimit() int32 {
 	if x != nil {
-		return x.RawAddress
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateAddressRequest) GetCoordinates() *Coordinates {
+func (x *ListUsersRequest) GetPageToken() string {
 	if x != nil {
-		return x.Coordinates
+		return x.PageToken
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateAddressRequest) GetAccuracy() string {
-	if x != nil {
-		return x.Accuracy
+func (x *ListUsersRequest) GetIncludeTotal() bool {
+	if x != nil && x.IncludeTotal != nil {
+		return *x.IncludeTotal
 	}
-	return ""
+	return false
 }
 
-func (x *CreateAddressRequest) GetSource() string {
+func (x *ListUsersRequest) GetEmailPrefix() string {
 	if x != nil {
-		return x.Source
+		return x.EmailPrefix
 	}
 	return ""
 }
 
-type UpdateAddressRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	RawAddress    string                 `protobuf:"bytes,2,opt,name=raw_address,json=rawAddress,proto3" json:"raw_address,omitempty"`
-	Coordinates   *Coordinates           `protobuf:"bytes,3,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
-	Accuracy      string                 `protobuf:"bytes,4,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
-	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UpdateAddressRequest) Reset() {
-	*x = UpdateAddressRequest{}
-	mi := &file_proto_protobuf_proto_msgTypes[12]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UpdateAddressRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UpdateAddressRequest) ProtoMessage() {}
-
-func (x *UpdateAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_protobuf_proto_msgTypes[12]
+func (x *ListUsersRequest) GetNameContains() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.NameContains
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use UpdateAddressRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAddressRequest) Descriptor() ([]byte, []int) {
-	return file_proto_protobuf_proto_rawDescGZIP(), []int{12}
+func (x *ListUsersRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
 }
 
-func (x *UpdateAddressRequest) GetId() string {
+func (x *ListUsersRequest) GetStatus() string {
 	if x != nil {
-		return x.Id
+		return x.Status
 	}
 	return ""
 }
 
-func (x *UpdateAddressRequest) GetRawAddress() string {
+func (x *ListUsersRequest) GetCreatedAfter() *timestamppb.Timestamp {
 	if x != nil {
-		return x.RawAddress
+		return x.CreatedAfter
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateAddressRequest) GetCoordinates() *Coordinates {
+func (x *ListUsersRequest) GetCreatedBefore() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Coordinates
+		return x.CreatedBefore
 	}
 	return nil
 }
 
-func (x *UpdateAddressRequest) GetAccuracy() string {
+func (x *ListUsersRequest) GetQ() string {
 	if x != nil {
-		return x.Accuracy
+		return x.Q
 	}
 	return ""
 }
 
-func (x *UpdateAddressRequest) GetSource() string {
+func (x *ListUsersRequest) GetOrderBy() string {
 	if x != nil {
-		return x.Source
+		return x.OrderBy
 	}
 	return ""
 }
 
-type AddressListRequest struct {
+type UserListResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`                                       // only set when counted
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`                                         // offset mode only
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddressListRequest) Reset() {
-	*x = AddressListRequest{}
+func (x *UserListResponse) Reset() {
+	*x = UserListResponse{}
 	mi := &file_proto_protobuf_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddressListRequest) String() string {
+func (x *UserListResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddressListRequest) ProtoMessage() {}
+func (*UserListResponse) ProtoMessage() {}
 
-func (x *AddressListRequest) ProtoReflect() protoreflect.Message {
+func (x *UserListResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_protobuf_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -797,47 +883,1668 @@ func (x *AddressListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddressListRequest.ProtoReflect.Descriptor instead.
-func (*AddressListRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserListResponse.ProtoReflect.Descriptor instead.
+func (*UserListResponse) Descriptor() ([]byte, []int) {
 	return file_proto_protobuf_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *AddressListRequest) GetPage() int32 {
+func (x *UserListResponse) GetUsers() []*User {
 	if x != nil {
-		return x.Page
+		return x.Users
 	}
-	return 0
+	return nil
 }
 
-func (x *AddressListRequest) GetLimit() int32 {
+func (x *UserListResponse) GetTotal() int64 {
 	if x != nil {
-		return x.Limit
+		return x.Total
 	}
 	return 0
 }
 
-type AddressListData struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Addresses     []*Address             `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *UserListResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
 }
 
-func (x *AddressListData) Reset() {
-	*x = AddressListData{}
+func (x *UserListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type Address struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RawAddress        string                 `protobuf:"bytes,2,opt,name=raw_address,json=rawAddress,proto3" json:"raw_address,omitempty"`
+	NormalizedAddress string                 `protobuf:"bytes,3,opt,name=normalized_address,json=normalizedAddress,proto3" json:"normalized_address,omitempty"`
+	Coordinates       *Coordinates           `protobuf:"bytes,4,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	Accuracy          string                 `protobuf:"bytes,5,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
+	Source            string                 `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	UserId            int64                  `protobuf:"varint,7,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`            // owner
+	GeocodedBy        string                 `protobuf:"bytes,8,opt,name=geocoded_by,json=geocodedBy,proto3" json:"geocoded_by,omitempty"` // provider that resolved the coordinates, empty if client supplied
+	Components        *AddressComponents     `protobuf:"bytes,9,opt,name=components,proto3" json:"components,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_proto_protobuf_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_protobuf_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Address) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Address) GetRawAddress() string {
+	if x != nil {
+		return x.RawAddress
+	}
+	return ""
+}
+
+func (x *Address) GetNormalizedAddress() string {
+	if x != nil {
+		return x.NormalizedAddress
+	}
+	return ""
+}
+
+func (x *Address) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *Address) GetAccuracy() string {
+	if x != nil {
+		return x.Accuracy
+	}
+	return ""
+}
+
+func (x *Address) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Address) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Address) GetGeocodedBy() string {
+	if x != nil {
+		return x.GeocodedBy
+	}
+	return ""
+}
+
+func (x *Address) GetComponents() *AddressComponents {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+// Structured parts of an address. Omitted fields are parsed from raw_address.
+type AddressComponents struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HouseNumber   string                 `protobuf:"bytes,1,opt,name=house_number,json=houseNumber,proto3" json:"house_number,omitempty"`
+	Street        string                 `protobuf:"bytes,2,opt,name=street,proto3" json:"street,omitempty"`
+	Unit          string                 `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	Locality      string                 `protobuf:"bytes,4,opt,name=locality,proto3" json:"locality,omitempty"` // city / town
+	Region        string                 `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`     // state / province
+	PostalCode    string                 `protobuf:"bytes,6,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	CountryCode   string                 `protobuf:"bytes,7,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"` // ISO 3166-1 alpha-2
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressComponents) Reset() {
+	*x = AddressComponents{}
+	mi := &file_proto_protobuf_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressComponents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressComponents) ProtoMessage() {}
+
+func (x *AddressComponents) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressComponents.ProtoReflect.Descriptor instead.
+func (*AddressComponents) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AddressComponents) GetHouseNumber() string {
+	if x != nil {
+		return x.HouseNumber
+	}
+	return ""
+}
+
+func (x *AddressComponents) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *AddressComponents) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *AddressComponents) GetLocality() string {
+	if x != nil {
+		return x.Locality
+	}
+	return ""
+}
+
+func (x *AddressComponents) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *AddressComponents) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *AddressComponents) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+type CreateAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ignored unless the caller is an admin; defaults to the caller
+	RawAddress    string                 `protobuf:"bytes,2,opt,name=raw_address,json=rawAddress,proto3" json:"raw_address,omitempty"`
+	Coordinates   *Coordinates           `protobuf:"bytes,3,opt,name=coordinates,proto3" json:"coordinates,omitempty"` // optional; resolved from raw_address by the geocoder when omitted
+	Accuracy      string                 `protobuf:"bytes,4,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
+	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	Components    *AddressComponents     `protobuf:"bytes,6,opt,name=components,proto3" json:"components,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAddressRequest) Reset() {
+	*x = CreateAddressRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAddressRequest) ProtoMessage() {}
+
+func (x *CreateAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAddressRequest.ProtoReflect.Descriptor instead.
+func (*CreateAddressRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateAddressRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateAddressRequest) GetRawAddress() string {
+	if x != nil {
+		return x.RawAddress
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *CreateAddressRequest) GetAccuracy() string {
+	if x != nil {
+		return x.Accuracy
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *CreateAddressRequest) GetComponents() *AddressComponents {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+type UpdateAddressRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RawAddress  string                 `protobuf:"bytes,2,opt,name=raw_address,json=rawAddress,proto3" json:"raw_address,omitempty"`
+	Coordinates *Coordinates           `protobuf:"bytes,3,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	Accuracy    string                 `protobuf:"bytes,4,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
+	Source      string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	Components  *AddressComponents     `protobuf:"bytes,6,opt,name=components,proto3" json:"components,omitempty"`
+	// Fields to change, e.g. "raw_address", "coordinates" or
+	// "components.street"; the rest keep their stored values. Without a mask
+	// the whole address is replaced.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAddressRequest) Reset() {
+	*x = UpdateAddressRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAddressRequest) ProtoMessage() {}
+
+func (x *UpdateAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAddressRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAddressRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateAddressRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetRawAddress() string {
+	if x != nil {
+		return x.RawAddress
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *UpdateAddressRequest) GetAccuracy() string {
+	if x != nil {
+		return x.Accuracy
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *UpdateAddressRequest) GetComponents() *AddressComponents {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *UpdateAddressRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type AddressListRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Optional component filters, matched case-insensitively
+	HouseNumber string `protobuf:"bytes,3,opt,name=house_number,json=houseNumber,proto3" json:"house_number,omitempty"`
+	Street      string `protobuf:"bytes,4,opt,name=street,proto3" json:"street,omitempty"`
+	Unit        string `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
+	Locality    string `protobuf:"bytes,6,opt,name=locality,proto3" json:"locality,omitempty"`
+	Region      string `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode  string `protobuf:"bytes,8,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	CountryCode string `protobuf:"bytes,9,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	// Cursor mode: next_page_token of the previous page; page is then ignored
+	PageToken string `protobuf:"bytes,10,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Count all matches into total. Unset counts in offset mode only.
+	IncludeTotal  *bool `protobuf:"varint,11,opt,name=include_total,json=includeTotal,proto3,oneof" json:"include_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressListRequest) Reset() {
+	*x = AddressListRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressListRequest) ProtoMessage() {}
+
+func (x *AddressListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressListRequest.ProtoReflect.Descriptor instead.
+func (*AddressListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AddressListRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *AddressListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *AddressListRequest) GetHouseNumber() string {
+	if x != nil {
+		return x.HouseNumber
+	}
+	return ""
+}
+
+func (x *AddressListRequest) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *AddressListRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *AddressListRequest) GetLocality() string {
+	if x != nil {
+		return x.Locality
+	}
+	return ""
+}
+
+func (x *AddressListRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *AddressListRequest) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *AddressListRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *AddressListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *AddressListRequest) GetIncludeTotal() bool {
+	if x != nil && x.IncludeTotal != nil {
+		return *x.IncludeTotal
+	}
+	return false
+}
+
+type ListUserAddressesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserAddressesRequest) Reset() {
+	*x = ListUserAddressesRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserAddressesRequest) ProtoMessage() {}
+
+func (x *ListUserAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserAddressesRequest.ProtoReflect.Descriptor instead.
+func (*ListUserAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListUserAddressesRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListUserAddressesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUserAddressesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchAddressesNearbyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Center        *Coordinates           `protobuf:"bytes,1,opt,name=center,proto3" json:"center,omitempty"`
+	RadiusMeters  float64                `protobuf:"fixed64,2,opt,name=radius_meters,json=radiusMeters,proto3" json:"radius_meters,omitempty"` // capped at 50 km
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                    // default 20, max 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchAddressesNearbyRequest) Reset() {
+	*x = SearchAddressesNearbyRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchAddressesNearbyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAddressesNearbyRequest) ProtoMessage() {}
+
+func (x *SearchAddressesNearbyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAddressesNearbyRequest.ProtoReflect.Descriptor instead.
+func (*SearchAddressesNearbyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SearchAddressesNearbyRequest) GetCenter() *Coordinates {
+	if x != nil {
+		return x.Center
+	}
+	return nil
+}
+
+func (x *SearchAddressesNearbyRequest) GetRadiusMeters() float64 {
+	if x != nil {
+		return x.RadiusMeters
+	}
+	return 0
+}
+
+func (x *SearchAddressesNearbyRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type NearbyAddress struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Address        *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	DistanceMeters float64                `protobuf:"fixed64,2,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NearbyAddress) Reset() {
+	*x = NearbyAddress{}
+	mi := &file_proto_protobuf_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyAddress) ProtoMessage() {}
+
+func (x *NearbyAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyAddress.ProtoReflect.Descriptor instead.
+func (*NearbyAddress) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *NearbyAddress) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *NearbyAddress) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+type SearchAddressesNearbyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Results       []*NearbyAddress       `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"` // closest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchAddressesNearbyResponse) Reset() {
+	*x = SearchAddressesNearbyResponse{}
+	mi := &file_proto_protobuf_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchAddressesNearbyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAddressesNearbyResponse) ProtoMessage() {}
+
+func (x *SearchAddressesNearbyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAddressesNearbyResponse.ProtoReflect.Descriptor instead.
+func (*SearchAddressesNearbyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SearchAddressesNearbyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SearchAddressesNearbyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SearchAddressesNearbyResponse) GetResults() []*NearbyAddress {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ReverseGeocodeRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Point           *Coordinates           `protobuf:"bytes,1,opt,name=point,proto3" json:"point,omitempty"`
+	ToleranceMeters float64                `protobuf:"fixed64,2,opt,name=tolerance_meters,json=toleranceMeters,proto3" json:"tolerance_meters,omitempty"` // how far to look for a stored address; 0 = server default, max 1000
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReverseGeocodeRequest) Reset() {
+	*x = ReverseGeocodeRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseGeocodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseGeocodeRequest) ProtoMessage() {}
+
+func (x *ReverseGeocodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseGeocodeRequest.ProtoReflect.Descriptor instead.
+func (*ReverseGeocodeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReverseGeocodeRequest) GetPoint() *Coordinates {
+	if x != nil {
+		return x.Point
+	}
+	return nil
+}
+
+func (x *ReverseGeocodeRequest) GetToleranceMeters() float64 {
+	if x != nil {
+		return x.ToleranceMeters
+	}
+	return 0
+}
+
+type ReverseGeocodeResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Address        *Address               `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"` // id is empty when the candidate comes from a geocoder
+	DistanceMeters float64                `protobuf:"fixed64,4,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	Confidence     float64                `protobuf:"fixed64,5,opt,name=confidence,proto3" json:"confidence,omitempty"` // 0..1
+	Stored         bool                   `protobuf:"varint,6,opt,name=stored,proto3" json:"stored,omitempty"`          // true when address is an existing record
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReverseGeocodeResponse) Reset() {
+	*x = ReverseGeocodeResponse{}
+	mi := &file_proto_protobuf_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseGeocodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseGeocodeResponse) ProtoMessage() {}
+
+func (x *ReverseGeocodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseGeocodeResponse.ProtoReflect.Descriptor instead.
+func (*ReverseGeocodeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReverseGeocodeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReverseGeocodeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReverseGeocodeResponse) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *ReverseGeocodeResponse) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *ReverseGeocodeResponse) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *ReverseGeocodeResponse) GetStored() bool {
+	if x != nil {
+		return x.Stored
+	}
+	return false
+}
+
+type AddressListData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addresses     []*Address             `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressListData) Reset() {
+	*x = AddressListData{}
+	mi := &file_proto_protobuf_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressListData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressListData) ProtoMessage() {}
+
+func (x *AddressListData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressListData.ProtoReflect.Descriptor instead.
+func (*AddressListData) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AddressListData) GetAddresses() []*Address {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type AddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *Address               `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressResponse) Reset() {
+	*x = AddressResponse{}
+	mi := &file_proto_protobuf_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressResponse) ProtoMessage() {}
+
+func (x *AddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressResponse.ProtoReflect.Descriptor instead.
+func (*AddressResponse) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AddressResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AddressResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AddressResponse) GetData() *Address {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Wrapper for Delete
+type DeleteAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAddressResponse) Reset() {
+	*x = DeleteAddressResponse{}
+	mi := &file_proto_protobuf_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAddressResponse) ProtoMessage() {}
+
+func (x *DeleteAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAddressResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAddressResponse) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteAddressResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteAddressResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type AddressListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *AddressListData       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+	Truncated     bool                   `protobuf:"varint,6,opt,name=truncated,proto3" json:"truncated,omitempty"`                               // area queries: more matches exist than the hard result cap
+	NextPageToken string                 `protobuf:"bytes,7,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // ListAddress: empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressListResponse) Reset() {
+	*x = AddressListResponse{}
+	mi := &file_proto_protobuf_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressListResponse) ProtoMessage() {}
+
+func (x *AddressListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressListResponse.ProtoReflect.Descriptor instead.
+func (*AddressListResponse) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AddressListResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AddressListResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AddressListResponse) GetData() *AddressListData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *AddressListResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *AddressListResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *AddressListResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *AddressListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListAddressesInBoundingBoxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SouthWest     *Coordinates           `protobuf:"bytes,1,opt,name=south_west,json=southWest,proto3" json:"south_west,omitempty"`
+	NorthEast     *Coordinates           `protobuf:"bytes,2,opt,name=north_east,json=northEast,proto3" json:"north_east,omitempty"` // longitude below south_west's means the box crosses the antimeridian
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // max 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAddressesInBoundingBoxRequest) Reset() {
+	*x = ListAddressesInBoundingBoxRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAddressesInBoundingBoxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAddressesInBoundingBoxRequest) ProtoMessage() {}
+
+func (x *ListAddressesInBoundingBoxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAddressesInBoundingBoxRequest.ProtoReflect.Descriptor instead.
+func (*ListAddressesInBoundingBoxRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListAddressesInBoundingBoxRequest) GetSouthWest() *Coordinates {
+	if x != nil {
+		return x.SouthWest
+	}
+	return nil
+}
+
+func (x *ListAddressesInBoundingBoxRequest) GetNorthEast() *Coordinates {
+	if x != nil {
+		return x.NorthEast
+	}
+	return nil
+}
+
+func (x *ListAddressesInBoundingBoxRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAddressesInBoundingBoxRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAddressesInPolygonRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Polygon:
+	//
+	//	*ListAddressesInPolygonRequest_Geojson
+	//	*ListAddressesInPolygonRequest_Points
+	Polygon       isListAddressesInPolygonRequest_Polygon `protobuf_oneof:"polygon"`
+	Page          int32                                   `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                                   `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // max 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAddressesInPolygonRequest) Reset() {
+	*x = ListAddressesInPolygonRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAddressesInPolygonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAddressesInPolygonRequest) ProtoMessage() {}
+
+func (x *ListAddressesInPolygonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAddressesInPolygonRequest.ProtoReflect.Descriptor instead.
+func (*ListAddressesInPolygonRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListAddressesInPolygonRequest) GetPolygon() isListAddressesInPolygonRequest_Polygon {
+	if x != nil {
+		return x.Polygon
+	}
+	return nil
+}
+
+func (x *ListAddressesInPolygonRequest) GetGeojson() string {
+	if x != nil {
+		if x, ok := x.Polygon.(*ListAddressesInPolygonRequest_Geojson); ok {
+			return x.Geojson
+		}
+	}
+	return ""
+}
+
+func (x *ListAddressesInPolygonRequest) GetPoints() *PolygonPoints {
+	if x != nil {
+		if x, ok := x.Polygon.(*ListAddressesInPolygonRequest_Points); ok {
+			return x.Points
+		}
+	}
+	return nil
+}
+
+func (x *ListAddressesInPolygonRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAddressesInPolygonRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type isListAddressesInPolygonRequest_Polygon interface {
+	isListAddressesInPolygonRequest_Polygon()
+}
+
+type ListAddressesInPolygonRequest_Geojson struct {
+	Geojson string `protobuf:"bytes,1,opt,name=geojson,proto3,oneof"` // GeoJSON Polygon geometry or Feature
+}
+
+type ListAddressesInPolygonRequest_Points struct {
+	Points *PolygonPoints `protobuf:"bytes,2,opt,name=points,proto3,oneof"` // single ring; closed automatically
+}
+
+func (*ListAddressesInPolygonRequest_Geojson) isListAddressesInPolygonRequest_Polygon() {}
+
+func (*ListAddressesInPolygonRequest_Points) isListAddressesInPolygonRequest_Polygon() {}
+
+type PolygonPoints struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*Coordinates         `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolygonPoints) Reset() {
+	*x = PolygonPoints{}
+	mi := &file_proto_protobuf_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolygonPoints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolygonPoints) ProtoMessage() {}
+
+func (x *PolygonPoints) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolygonPoints.ProtoReflect.Descriptor instead.
+func (*PolygonPoints) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PolygonPoints) GetPoints() []*Coordinates {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type FindDuplicateAddressesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	MinSimilarity     float64                `protobuf:"fixed64,1,opt,name=min_similarity,json=minSimilarity,proto3" json:"min_similarity,omitempty"`               // trigram similarity of normalized_address, 0..1; default 0.6
+	MaxDistanceMeters float64                `protobuf:"fixed64,2,opt,name=max_distance_meters,json=maxDistanceMeters,proto3" json:"max_distance_meters,omitempty"` // default 100, max 1000
+	UserId            int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                     // optional owner filter
+	Limit             int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                                                     // max clusters, default 50, max 500
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FindDuplicateAddressesRequest) Reset() {
+	*x = FindDuplicateAddressesRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicateAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicateAddressesRequest) ProtoMessage() {}
+
+func (x *FindDuplicateAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicateAddressesRequest.ProtoReflect.Descriptor instead.
+func (*FindDuplicateAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *FindDuplicateAddressesRequest) GetMinSimilarity() float64 {
+	if x != nil {
+		return x.MinSimilarity
+	}
+	return 0
+}
+
+func (x *FindDuplicateAddressesRequest) GetMaxDistanceMeters() float64 {
+	if x != nil {
+		return x.MaxDistanceMeters
+	}
+	return 0
+}
+
+func (x *FindDuplicateAddressesRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *FindDuplicateAddressesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type DuplicateCluster struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Addresses         []*Address             `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	MinSimilarity     float64                `protobuf:"fixed64,2,opt,name=min_similarity,json=minSimilarity,proto3" json:"min_similarity,omitempty"`               // weakest pair in the cluster
+	MaxDistanceMeters float64                `protobuf:"fixed64,3,opt,name=max_distance_meters,json=maxDistanceMeters,proto3" json:"max_distance_meters,omitempty"` // farthest pair in the cluster
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DuplicateCluster) Reset() {
+	*x = DuplicateCluster{}
+	mi := &file_proto_protobuf_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateCluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateCluster) ProtoMessage() {}
+
+func (x *DuplicateCluster) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateCluster.ProtoReflect.Descriptor instead.
+func (*DuplicateCluster) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DuplicateCluster) GetAddresses() []*Address {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *DuplicateCluster) GetMinSimilarity() float64 {
+	if x != nil {
+		return x.MinSimilarity
+	}
+	return 0
+}
+
+func (x *DuplicateCluster) GetMaxDistanceMeters() float64 {
+	if x != nil {
+		return x.MaxDistanceMeters
+	}
+	return 0
+}
+
+type FindDuplicateAddressesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Clusters      []*DuplicateCluster    `protobuf:"bytes,3,rep,name=clusters,proto3" json:"clusters,omitempty"` // largest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindDuplicateAddressesResponse) Reset() {
+	*x = FindDuplicateAddressesResponse{}
+	mi := &file_proto_protobuf_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicateAddressesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicateAddressesResponse) ProtoMessage() {}
+
+func (x *FindDuplicateAddressesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicateAddressesResponse.ProtoReflect.Descriptor instead.
+func (*FindDuplicateAddressesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *FindDuplicateAddressesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *FindDuplicateAddressesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *FindDuplicateAddressesResponse) GetClusters() []*DuplicateCluster {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+// First message carries options, the rest carry the file in chunks
+type ImportAddressesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportAddressesRequest_Options
+	//	*ImportAddressesRequest_Chunk
+	Payload       isImportAddressesRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportAddressesRequest) Reset() {
+	*x = ImportAddressesRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportAddressesRequest) ProtoMessage() {}
+
+func (x *ImportAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportAddressesRequest.ProtoReflect.Descriptor instead.
+func (*ImportAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ImportAddressesRequest) GetPayload() isImportAddressesRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportAddressesRequest) GetOptions() *ImportOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportAddressesRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportAddressesRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportAddressesRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isImportAddressesRequest_Payload interface {
+	isImportAddressesRequest_Payload()
+}
+
+type ImportAddressesRequest_Options struct {
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportAddressesRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*ImportAddressesRequest_Options) isImportAddressesRequest_Payload() {}
+
+func (*ImportAddressesRequest_Chunk) isImportAddressesRequest_Payload() {}
+
+type ImportOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`                            // "csv" or "geojson"
+	ErrorsOnly    bool                   `protobuf:"varint,2,opt,name=errors_only,json=errorsOnly,proto3" json:"errors_only,omitempty"` // leave created rows out of the report
+	Geocode       bool                   `protobuf:"varint,3,opt,name=geocode,proto3" json:"geocode,omitempty"`                         // geocode rows without coordinates (rate-limited); otherwise they are rejected
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_proto_protobuf_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddressListData) String() string {
+func (x *ImportOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddressListData) ProtoMessage() {}
+func (*ImportOptions) ProtoMessage() {}
 
-func (x *AddressListData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_protobuf_proto_msgTypes[14]
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -848,42 +2555,57 @@ func (x *AddressListData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddressListData.ProtoReflect.Descriptor instead.
-func (*AddressListData) Descriptor() ([]byte, []int) {
-	return file_proto_protobuf_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *AddressListData) GetAddresses() []*Address {
+func (x *ImportOptions) GetFormat() string {
 	if x != nil {
-		return x.Addresses
+		return x.Format
 	}
-	return nil
+	return ""
 }
 
-type AddressResponse struct {
+func (x *ImportOptions) GetErrorsOnly() bool {
+	if x != nil {
+		return x.ErrorsOnly
+	}
+	return false
+}
+
+func (x *ImportOptions) GetGeocode() bool {
+	if x != nil {
+		return x.Geocode
+	}
+	return false
+}
+
+type ImportRowResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Data          *Address               `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`                           // record number, header excluded
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                        // "created", "duplicate" or "rejected"
+	AddressId     string                 `protobuf:"bytes,3,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"` // created rows only
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddressResponse) Reset() {
-	*x = AddressResponse{}
-	mi := &file_proto_protobuf_proto_msgTypes[15]
+func (x *ImportRowResult) Reset() {
+	*x = ImportRowResult{}
+	mi := &file_proto_protobuf_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddressResponse) String() string {
+func (x *ImportRowResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddressResponse) ProtoMessage() {}
+func (*ImportRowResult) ProtoMessage() {}
 
-func (x *AddressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_protobuf_proto_msgTypes[15]
+func (x *ImportRowResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -894,56 +2616,66 @@ func (x *AddressResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddressResponse.ProtoReflect.Descriptor instead.
-func (*AddressResponse) Descriptor() ([]byte, []int) {
-	return file_proto_protobuf_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ImportRowResult.ProtoReflect.Descriptor instead.
+func (*ImportRowResult) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *AddressResponse) GetSuccess() bool {
+func (x *ImportRowResult) GetLine() int32 {
 	if x != nil {
-		return x.Success
+		return x.Line
 	}
-	return false
+	return 0
 }
 
-func (x *AddressResponse) GetMessage() string {
+func (x *ImportRowResult) GetStatus() string {
 	if x != nil {
-		return x.Message
+		return x.Status
 	}
 	return ""
 }
 
-func (x *AddressResponse) GetData() *Address {
+func (x *ImportRowResult) GetAddressId() string {
 	if x != nil {
-		return x.Data
+		return x.AddressId
 	}
-	return nil
+	return ""
 }
 
-// Wrapper for Delete
-type DeleteAddressResponse struct {
+func (x *ImportRowResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ImportAddressesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // false when the file became unreadable part way
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Created       int32                  `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+	Duplicates    int32                  `protobuf:"varint,4,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Rejected      int32                  `protobuf:"varint,5,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Rows          []*ImportRowResult     `protobuf:"bytes,6,rep,name=rows,proto3" json:"rows,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAddressResponse) Reset() {
-	*x = DeleteAddressResponse{}
-	mi := &file_proto_protobuf_proto_msgTypes[16]
+func (x *ImportAddressesResponse) Reset() {
+	*x = ImportAddressesResponse{}
+	mi := &file_proto_protobuf_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAddressResponse) String() string {
+func (x *ImportAddressesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAddressResponse) ProtoMessage() {}
+func (*ImportAddressesResponse) ProtoMessage() {}
 
-func (x *DeleteAddressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_protobuf_proto_msgTypes[16]
+func (x *ImportAddressesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -954,51 +2686,84 @@ func (x *DeleteAddressResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAddressResponse.ProtoReflect.Descriptor instead.
-func (*DeleteAddressResponse) Descriptor() ([]byte, []int) {
-	return file_proto_protobuf_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ImportAddressesResponse.ProtoReflect.Descriptor instead.
+func (*ImportAddressesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *DeleteAddressResponse) GetSuccess() bool {
+func (x *ImportAddressesResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *DeleteAddressResponse) GetMessage() string {
+func (x *ImportAddressesResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-type AddressListResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Data          *AddressListData       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int32                  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+func (x *ImportAddressesResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportAddressesResponse) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *ImportAddressesResponse) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *ImportAddressesResponse) GetRows() []*ImportRowResult {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type ExportAddressesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Format string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"` // geojson (default), csv or kml
+	// Same component filters as AddressListRequest
+	HouseNumber   string `protobuf:"bytes,2,opt,name=house_number,json=houseNumber,proto3" json:"house_number,omitempty"`
+	Street        string `protobuf:"bytes,3,opt,name=street,proto3" json:"street,omitempty"`
+	Unit          string `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	Locality      string `protobuf:"bytes,5,opt,name=locality,proto3" json:"locality,omitempty"`
+	Region        string `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode    string `protobuf:"bytes,7,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	CountryCode   string `protobuf:"bytes,8,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	UserId        int64  `protobuf:"varint,9,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // admins only; 0 exports every owner
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddressListResponse) Reset() {
-	*x = AddressListResponse{}
-	mi := &file_proto_protobuf_proto_msgTypes[17]
+func (x *ExportAddressesRequest) Reset() {
+	*x = ExportAddressesRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddressListResponse) String() string {
+func (x *ExportAddressesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddressListResponse) ProtoMessage() {}
+func (*ExportAddressesRequest) ProtoMessage() {}
 
-func (x *AddressListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_protobuf_proto_msgTypes[17]
+func (x *ExportAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1009,79 +2774,261 @@ func (x *AddressListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddressListResponse.ProtoReflect.Descriptor instead.
-func (*AddressListResponse) Descriptor() ([]byte, []int) {
-	return file_proto_protobuf_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ExportAddressesRequest.ProtoReflect.Descriptor instead.
+func (*ExportAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *AddressListResponse) GetSuccess() bool {
+func (x *ExportAddressesRequest) GetFormat() string {
 	if x != nil {
-		return x.Success
+		return x.Format
 	}
-	return false
+	return ""
 }
 
-func (x *AddressListResponse) GetMessage() string {
+func (x *ExportAddressesRequest) GetHouseNumber() string {
 	if x != nil {
-		return x.Message
+		return x.HouseNumber
 	}
 	return ""
 }
 
-func (x *AddressListResponse) GetData() *AddressListData {
+func (x *ExportAddressesRequest) GetStreet() string {
 	if x != nil {
-		return x.Data
+		return x.Street
 	}
-	return nil
+	return ""
 }
 
-func (x *AddressListResponse) GetTotal() int64 {
+func (x *ExportAddressesRequest) GetUnit() string {
 	if x != nil {
-		return x.Total
+		return x.Unit
 	}
-	return 0
+	return ""
 }
 
-func (x *AddressListResponse) GetPage() int32 {
+func (x *ExportAddressesRequest) GetLocality() string {
 	if x != nil {
-		return x.Page
+		return x.Locality
+	}
+	return ""
+}
+
+func (x *ExportAddressesRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ExportAddressesRequest) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *ExportAddressesRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *ExportAddressesRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
 	}
 	return 0
 }
 
+type ExportAddressesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Chunk []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	// Set on the first message only
+	ContentType   string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	FileName      string `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportAddressesResponse) Reset() {
+	*x = ExportAddressesResponse{}
+	mi := &file_proto_protobuf_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportAddressesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAddressesResponse) ProtoMessage() {}
+
+func (x *ExportAddressesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAddressesResponse.ProtoReflect.Descriptor instead.
+func (*ExportAddressesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ExportAddressesResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *ExportAddressesResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportAddressesResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+type MergeAddressesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SurvivorId    string                 `protobuf:"bytes,1,opt,name=survivor_id,json=survivorId,proto3" json:"survivor_id,omitempty"`
+	DuplicateIds  []string               `protobuf:"bytes,2,rep,name=duplicate_ids,json=duplicateIds,proto3" json:"duplicate_ids,omitempty"` // deleted; their ids resolve to the survivor afterwards
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeAddressesRequest) Reset() {
+	*x = MergeAddressesRequest{}
+	mi := &file_proto_protobuf_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeAddressesRequest) ProtoMessage() {}
+
+func (x *MergeAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_protobuf_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeAddressesRequest.ProtoReflect.Descriptor instead.
+func (*MergeAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_protobuf_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *MergeAddressesRequest) GetSurvivorId() string {
+	if x != nil {
+		return x.SurvivorId
+	}
+	return ""
+}
+
+func (x *MergeAddressesRequest) GetDuplicateIds() []string {
+	if x != nil {
+		return x.DuplicateIds
+	}
+	return nil
+}
+
 var File_proto_protobuf_proto protoreflect.FileDescriptor
 
 const file_proto_protobuf_proto_rawDesc = "" +
 	"\n" +
-	"\x14proto/protobuf.proto\x12\x02pb\x1a\x1cgoogle/api/annotations.proto\"\a\n" +
-	"\x05Empty\"G\n" +
-	"\vCoordinates\x12\x1a\n" +
-	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
-	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\x18\n" +
-	"\x06UserId\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\x1b\n" +
-	"\tAddressId\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x14proto/protobuf.proto\x12\x02pb\x1a\x1cgoogle/api/annotations.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x14proto/validate.proto\"\a\n" +
+	"\x05Empty\"w\n" +
+	"\vCoordinates\x122\n" +
+	"\blatitude\x18\x01 \x01(\x01B\x16\x8a\xb5\x18\x12A\x00\x00\x00\x00\x00\x80V\xc0I\x00\x00\x00\x00\x00\x80V@R\blatitude\x124\n" +
+	"\tlongitude\x18\x02 \x01(\x01B\x16\x8a\xb5\x18\x12A\x00\x00\x00\x00\x00\x80f\xc0I\x00\x00\x00\x00\x00\x80f@R\tlongitude\"'\n" +
+	"\x06UserId\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\x03B\r\x8a\xb5\x18\t9\x00\x00\x00\x00\x00\x00\x00\x00R\x02id\")\n" +
+	"\tAddressId\x12\x1c\n" +
+	"\x02id\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\b\x01*\x04uuidR\x02id\"\xf1\x01\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1b\n" +
-	"\tfull_name\x18\x03 \x01(\tR\bfullName\"b\n" +
-	"\x11CreateUserRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
-	"\tfull_name\x18\x03 \x01(\tR\bfullName\"r\n" +
-	"\x11UpdateUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
-	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x1b\n" +
-	"\tfull_name\x18\x04 \x01(\tR\bfullName\"@\n" +
-	"\fLoginRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"%\n" +
+	"\tfull_name\x18\x03 \x01(\tR\bfullName\x12\x14\n" +
+	"\x05roles\x18\x04 \x03(\tR\x05roles\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\flocked_until\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vlockedUntil\"\x87\x01\n" +
+	"\x11CreateUserRequest\x12&\n" +
+	"\x05email\x18\x01 \x01(\tB\x10\x8a\xb5\x18\f\b\x01\x18\xff\x01*\x05emailR\x05email\x12$\n" +
+	"\bpassword\x18\x02 \x01(\tB\b\x8a\xb5\x18\x04\b\x01 HR\bpassword\x12$\n" +
+	"\tfull_name\x18\x03 \x01(\tB\a\x8a\xb5\x18\x03\x18\xff\x01R\bfullName\"\xc2\x02\n" +
+	"\x11UpdateUserRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\x03B\r\x8a\xb5\x18\t9\x00\x00\x00\x00\x00\x00\x00\x00R\x02id\x12$\n" +
+	"\x05email\x18\x02 \x01(\tB\x0e\x8a\xb5\x18\n" +
+	"\x18\xff\x01*\x05emailR\x05email\x12\"\n" +
+	"\bpassword\x18\x03 \x01(\tB\x06\x8a\xb5\x18\x02 HR\bpassword\x12$\n" +
+	"\tfull_name\x18\x04 \x01(\tB\a\x8a\xb5\x18\x03\x18\xff\x01R\bfullName\x12.\n" +
+	"\x06status\x18\x05 \x01(\tB\x16\x8a\xb5\x18\x122\x06active2\bdisabledR\x06status\x12;\n" +
+	"\vupdate_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x121\n" +
+	"\x10current_password\x18\a \x01(\tB\x06\x8a\xb5\x18\x02 HR\x0fcurrentPassword\"U\n" +
+	"\fLoginRequest\x12\x1f\n" +
+	"\x05email\x18\x01 \x01(\tB\t\x8a\xb5\x18\x05\b\x01\x18\xff\x01R\x05email\x12$\n" +
+	"\bpassword\x18\x02 \x01(\tB\b\x8a\xb5\x18\x04\b\x01 HR\bpassword\"\x88\x01\n" +
 	"\rLoginResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"2\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x03 \x01(\x03R\texpiresIn\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\x04 \x01(\tR\ttokenType\"E\n" +
+	"\x13RefreshTokenRequest\x12.\n" +
+	"\rrefresh_token\x18\x01 \x01(\tB\t\x8a\xb5\x18\x05\b\x01\x18\x80\x01R\frefreshToken\"S\n" +
+	"\vRoleRequest\x12&\n" +
+	"\auser_id\x18\x01 \x01(\x03B\r\x8a\xb5\x18\t9\x00\x00\x00\x00\x00\x00\x00\x00R\x06userId\x12\x1c\n" +
+	"\x04role\x18\x02 \x01(\tB\b\x8a\xb5\x18\x04\b\x01\x18 R\x04role\"=\n" +
+	"\rLogoutRequest\x12,\n" +
+	"\rrefresh_token\x18\x01 \x01(\tB\a\x8a\xb5\x18\x03\x18\x80\x01R\frefreshToken\"\x9f\x04\n" +
+	"\x10ListUsersRequest\x12!\n" +
+	"\x04page\x18\x01 \x01(\x05B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x04page\x12#\n" +
+	"\x05limit\x18\x02 \x01(\x05B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x05limit\x12&\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tB\a\x8a\xb5\x18\x03\x18\x80\bR\tpageToken\x12(\n" +
+	"\rinclude_total\x18\x04 \x01(\bH\x00R\fincludeTotal\x88\x01\x01\x12)\n" +
+	"\femail_prefix\x18\x05 \x01(\tB\x06\x8a\xb5\x18\x02\x18dR\vemailPrefix\x12+\n" +
+	"\rname_contains\x18\x06 \x01(\tB\x06\x8a\xb5\x18\x02\x18dR\fnameContains\x12\x1a\n" +
+	"\x04role\x18\a \x01(\tB\x06\x8a\xb5\x18\x02\x18 R\x04role\x12.\n" +
+	"\x06status\x18\b \x01(\tB\x16\x8a\xb5\x18\x122\x06active2\bdisabledR\x06status\x12?\n" +
+	"\rcreated_after\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x14\n" +
+	"\x01q\x18\v \x01(\tB\x06\x8a\xb5\x18\x02\x18dR\x01q\x12!\n" +
+	"\border_by\x18\f \x01(\tB\x06\x8a\xb5\x18\x02\x18@R\aorderByB\x10\n" +
+	"\x0e_include_total\"\x84\x01\n" +
 	"\x10UserListResponse\x12\x1e\n" +
-	"\x05users\x18\x01 \x03(\v2\b.pb.UserR\x05users\"\xd0\x01\n" +
+	"\x05users\x18\x01 \x03(\v2\b.pb.UserR\x05users\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\"\xc1\x02\n" +
 	"\aAddress\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vraw_address\x18\x02 \x01(\tR\n" +
@@ -1089,24 +3036,87 @@ const file_proto_protobuf_proto_rawDesc = "" +
 	"\x12normalized_address\x18\x03 \x01(\tR\x11normalizedAddress\x121\n" +
 	"\vcoordinates\x18\x04 \x01(\v2\x0f.pb.CoordinatesR\vcoordinates\x12\x1a\n" +
 	"\baccuracy\x18\x05 \x01(\tR\baccuracy\x12\x16\n" +
-	"\x06source\x18\x06 \x01(\tR\x06source\"\xb7\x01\n" +
-	"\x14CreateAddressRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1f\n" +
-	"\vraw_address\x18\x02 \x01(\tR\n" +
+	"\x06source\x18\x06 \x01(\tR\x06source\x12\x17\n" +
+	"\auser_id\x18\a \x01(\x03R\x06userId\x12\x1f\n" +
+	"\vgeocoded_by\x18\b \x01(\tR\n" +
+	"geocodedBy\x125\n" +
+	"\n" +
+	"components\x18\t \x01(\v2\x15.pb.AddressComponentsR\n" +
+	"components\"\x95\x02\n" +
+	"\x11AddressComponents\x12)\n" +
+	"\fhouse_number\x18\x01 \x01(\tB\x06\x8a\xb5\x18\x02\x18 R\vhouseNumber\x12\x1f\n" +
+	"\x06street\x18\x02 \x01(\tB\a\x8a\xb5\x18\x03\x18\xff\x01R\x06street\x12\x1a\n" +
+	"\x04unit\x18\x03 \x01(\tB\x06\x8a\xb5\x18\x02\x18 R\x04unit\x12#\n" +
+	"\blocality\x18\x04 \x01(\tB\a\x8a\xb5\x18\x03\x18\xff\x01R\blocality\x12\x1f\n" +
+	"\x06region\x18\x05 \x01(\tB\a\x8a\xb5\x18\x03\x18\xff\x01R\x06region\x12'\n" +
+	"\vpostal_code\x18\x06 \x01(\tB\x06\x8a\xb5\x18\x02\x18\x10R\n" +
+	"postalCode\x12)\n" +
+	"\fcountry_code\x18\a \x01(\tB\x06\x8a\xb5\x18\x02\x18\x02R\vcountryCode\"\x96\x02\n" +
+	"\x14CreateAddressRequest\x12&\n" +
+	"\auser_id\x18\x01 \x01(\x03B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x06userId\x12(\n" +
+	"\vraw_address\x18\x02 \x01(\tB\a\x8a\xb5\x18\x03\x18\xf4\x03R\n" +
 	"rawAddress\x121\n" +
-	"\vcoordinates\x18\x03 \x01(\v2\x0f.pb.CoordinatesR\vcoordinates\x12\x1a\n" +
-	"\baccuracy\x18\x04 \x01(\tR\baccuracy\x12\x16\n" +
-	"\x06source\x18\x05 \x01(\tR\x06source\"\xae\x01\n" +
-	"\x14UpdateAddressRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
-	"\vraw_address\x18\x02 \x01(\tR\n" +
+	"\vcoordinates\x18\x03 \x01(\v2\x0f.pb.CoordinatesR\vcoordinates\x12\"\n" +
+	"\baccuracy\x18\x04 \x01(\tB\x06\x8a\xb5\x18\x02\x18 R\baccuracy\x12\x1e\n" +
+	"\x06source\x18\x05 \x01(\tB\x06\x8a\xb5\x18\x02\x18 R\x06source\x125\n" +
+	"\n" +
+	"components\x18\x06 \x01(\v2\x15.pb.AddressComponentsR\n" +
+	"components\"\xc9\x02\n" +
+	"\x14UpdateAddressRequest\x12\x1c\n" +
+	"\x02id\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\b\x01*\x04uuidR\x02id\x12(\n" +
+	"\vraw_address\x18\x02 \x01(\tB\a\x8a\xb5\x18\x03\x18\xf4\x03R\n" +
 	"rawAddress\x121\n" +
-	"\vcoordinates\x18\x03 \x01(\v2\x0f.pb.CoordinatesR\vcoordinates\x12\x1a\n" +
-	"\baccuracy\x18\x04 \x01(\tR\baccuracy\x12\x16\n" +
-	"\x06source\x18\x05 \x01(\tR\x06source\">\n" +
-	"\x12AddressListRequest\x12\x12\n" +
-	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\"<\n" +
+	"\vcoordinates\x18\x03 \x01(\v2\x0f.pb.CoordinatesR\vcoordinates\x12\"\n" +
+	"\baccuracy\x18\x04 \x01(\tB\x06\x8a\xb5\x18\x02\x18 R\baccuracy\x12\x1e\n" +
+	"\x06source\x18\x05 \x01(\tB\x06\x8a\xb5\x18\x02\x18 R\x06source\x125\n" +
+	"\n" +
+	"components\x18\x06 \x01(\v2\x15.pb.AddressComponentsR\n" +
+	"components\x12;\n" +
+	"\vupdate_mask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"\xc2\x03\n" +
+	"\x12AddressListRequest\x12!\n" +
+	"\x04page\x18\x01 \x01(\x05B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x04page\x12#\n" +
+	"\x05limit\x18\x02 \x01(\x05B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x05limit\x12)\n" +
+	"\fhouse_number\x18\x03 \x01(\tB\x06\x8a\xb5\x18\x02\x18 R\vhouseNumber\x12\x1f\n" +
+	"\x06street\x18\x04 \x01(\tB\a\x8a\xb5\x18\x03\x18\xff\x01R\x06street\x12\x1a\n" +
+	"\x04unit\x18\x05 \x01(\tB\x06\x8a\xb5\x18\x02\x18 R\x04unit\x12#\n" +
+	"\blocality\x18\x06 \x01(\tB\a\x8a\xb5\x18\x03\x18\xff\x01R\blocality\x12\x1f\n" +
+	"\x06region\x18\a \x01(\tB\a\x8a\xb5\x18\x03\x18\xff\x01R\x06region\x12'\n" +
+	"\vpostal_code\x18\b \x01(\tB\x06\x8a\xb5\x18\x02\x18\x10R\n" +
+	"postalCode\x12)\n" +
+	"\fcountry_code\x18\t \x01(\tB\x06\x8a\xb5\x18\x02\x18\x02R\vcountryCode\x12&\n" +
+	"\n" +
+	"page_token\x18\n" +
+	" \x01(\tB\a\x8a\xb5\x18\x03\x18\x80\bR\tpageToken\x12(\n" +
+	"\rinclude_total\x18\v \x01(\bH\x00R\fincludeTotal\x88\x01\x01B\x10\n" +
+	"\x0e_include_total\"\x8a\x01\n" +
+	"\x18ListUserAddressesRequest\x12&\n" +
+	"\auser_id\x18\x01 \x01(\x03B\r\x8a\xb5\x18\t9\x00\x00\x00\x00\x00\x00\x00\x00R\x06userId\x12!\n" +
+	"\x04page\x18\x02 \x01(\x05B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x04page\x12#\n" +
+	"\x05limit\x18\x03 \x01(\x05B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x05limit\"\xa8\x01\n" +
+	"\x1cSearchAddressesNearbyRequest\x12/\n" +
+	"\x06center\x18\x01 \x01(\v2\x0f.pb.CoordinatesB\x06\x8a\xb5\x18\x02\b\x01R\x06center\x122\n" +
+	"\rradius_meters\x18\x02 \x01(\x01B\r\x8a\xb5\x18\t9\x00\x00\x00\x00\x00\x00\x00\x00R\fradiusMeters\x12#\n" +
+	"\x05limit\x18\x03 \x01(\x05B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x05limit\"_\n" +
+	"\rNearbyAddress\x12%\n" +
+	"\aaddress\x18\x01 \x01(\v2\v.pb.AddressR\aaddress\x12'\n" +
+	"\x0fdistance_meters\x18\x02 \x01(\x01R\x0edistanceMeters\"\x80\x01\n" +
+	"\x1dSearchAddressesNearbyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\aresults\x18\x03 \x03(\v2\x11.pb.NearbyAddressR\aresults\"\x80\x01\n" +
+	"\x15ReverseGeocodeRequest\x12-\n" +
+	"\x05point\x18\x01 \x01(\v2\x0f.pb.CoordinatesB\x06\x8a\xb5\x18\x02\b\x01R\x05point\x128\n" +
+	"\x10tolerance_meters\x18\x02 \x01(\x01B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x0ftoleranceMeters\"\xd4\x01\n" +
+	"\x16ReverseGeocodeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
+	"\aaddress\x18\x03 \x01(\v2\v.pb.AddressR\aaddress\x12'\n" +
+	"\x0fdistance_meters\x18\x04 \x01(\x01R\x0edistanceMeters\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x05 \x01(\x01R\n" +
+	"confidence\x12\x16\n" +
+	"\x06stored\x18\x06 \x01(\bR\x06stored\"<\n" +
 	"\x0fAddressListData\x12)\n" +
 	"\taddresses\x18\x01 \x03(\v2\v.pb.AddressR\taddresses\"f\n" +
 	"\x0fAddressResponse\x12\x18\n" +
@@ -1115,32 +3125,125 @@ const file_proto_protobuf_proto_rawDesc = "" +
 	"\x04data\x18\x03 \x01(\v2\v.pb.AddressR\x04data\"K\n" +
 	"\x15DeleteAddressResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x9c\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xe2\x01\n" +
 	"\x13AddressListResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12'\n" +
 	"\x04data\x18\x03 \x01(\v2\x13.pb.AddressListDataR\x04data\x12\x14\n" +
 	"\x05total\x18\x04 \x01(\x03R\x05total\x12\x12\n" +
-	"\x04page\x18\x05 \x01(\x05R\x04page2\x97\x03\n" +
+	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x1c\n" +
+	"\ttruncated\x18\x06 \x01(\bR\ttruncated\x12&\n" +
+	"\x0fnext_page_token\x18\a \x01(\tR\rnextPageToken\"\xdb\x01\n" +
+	"!ListAddressesInBoundingBoxRequest\x126\n" +
+	"\n" +
+	"south_west\x18\x01 \x01(\v2\x0f.pb.CoordinatesB\x06\x8a\xb5\x18\x02\b\x01R\tsouthWest\x126\n" +
+	"\n" +
+	"north_east\x18\x02 \x01(\v2\x0f.pb.CoordinatesB\x06\x8a\xb5\x18\x02\b\x01R\tnorthEast\x12!\n" +
+	"\x04page\x18\x03 \x01(\x05B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x04page\x12#\n" +
+	"\x05limit\x18\x04 \x01(\x05B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x05limit\"\xc5\x01\n" +
+	"\x1dListAddressesInPolygonRequest\x12$\n" +
+	"\ageojson\x18\x01 \x01(\tB\b\x8a\xb5\x18\x04 \x80\x80@H\x00R\ageojson\x12+\n" +
+	"\x06points\x18\x02 \x01(\v2\x11.pb.PolygonPointsH\x00R\x06points\x12!\n" +
+	"\x04page\x18\x03 \x01(\x05B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x04page\x12#\n" +
+	"\x05limit\x18\x04 \x01(\x05B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x05limitB\t\n" +
+	"\apolygon\"C\n" +
+	"\rPolygonPoints\x122\n" +
+	"\x06points\x18\x01 \x03(\v2\x0f.pb.CoordinatesB\t\x8a\xb5\x18\x05\b\x01P\xe8\aR\x06points\"\xea\x01\n" +
+	"\x1dFindDuplicateAddressesRequest\x12=\n" +
+	"\x0emin_similarity\x18\x01 \x01(\x01B\x16\x8a\xb5\x18\x12A\x00\x00\x00\x00\x00\x00\x00\x00I\x00\x00\x00\x00\x00\x00\xf0?R\rminSimilarity\x12=\n" +
+	"\x13max_distance_meters\x18\x02 \x01(\x01B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x11maxDistanceMeters\x12&\n" +
+	"\auser_id\x18\x03 \x01(\x03B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x06userId\x12#\n" +
+	"\x05limit\x18\x04 \x01(\x05B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x05limit\"\x94\x01\n" +
+	"\x10DuplicateCluster\x12)\n" +
+	"\taddresses\x18\x01 \x03(\v2\v.pb.AddressR\taddresses\x12%\n" +
+	"\x0emin_similarity\x18\x02 \x01(\x01R\rminSimilarity\x12.\n" +
+	"\x13max_distance_meters\x18\x03 \x01(\x01R\x11maxDistanceMeters\"\x86\x01\n" +
+	"\x1eFindDuplicateAddressesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\bclusters\x18\x03 \x03(\v2\x14.pb.DuplicateClusterR\bclusters\"j\n" +
+	"\x16ImportAddressesRequest\x12-\n" +
+	"\aoptions\x18\x01 \x01(\v2\x11.pb.ImportOptionsH\x00R\aoptions\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"j\n" +
+	"\rImportOptions\x12\x1e\n" +
+	"\x06format\x18\x01 \x01(\tB\x06\x8a\xb5\x18\x02\x18\x10R\x06format\x12\x1f\n" +
+	"\verrors_only\x18\x02 \x01(\bR\n" +
+	"errorsOnly\x12\x18\n" +
+	"\ageocode\x18\x03 \x01(\bR\ageocode\"t\n" +
+	"\x0fImportRowResult\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x03 \x01(\tR\taddressId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xcc\x01\n" +
+	"\x17ImportAddressesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\x05R\acreated\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x04 \x01(\x05R\n" +
+	"duplicates\x12\x1a\n" +
+	"\brejected\x18\x05 \x01(\x05R\brejected\x12'\n" +
+	"\x04rows\x18\x06 \x03(\v2\x13.pb.ImportRowResultR\x04rows\"\xe2\x02\n" +
+	"\x16ExportAddressesRequest\x12\x1e\n" +
+	"\x06format\x18\x01 \x01(\tB\x06\x8a\xb5\x18\x02\x18\x10R\x06format\x12)\n" +
+	"\fhouse_number\x18\x02 \x01(\tB\x06\x8a\xb5\x18\x02\x18 R\vhouseNumber\x12\x1f\n" +
+	"\x06street\x18\x03 \x01(\tB\a\x8a\xb5\x18\x03\x18\xff\x01R\x06street\x12\x1a\n" +
+	"\x04unit\x18\x04 \x01(\tB\x06\x8a\xb5\x18\x02\x18 R\x04unit\x12#\n" +
+	"\blocality\x18\x05 \x01(\tB\a\x8a\xb5\x18\x03\x18\xff\x01R\blocality\x12\x1f\n" +
+	"\x06region\x18\x06 \x01(\tB\a\x8a\xb5\x18\x03\x18\xff\x01R\x06region\x12'\n" +
+	"\vpostal_code\x18\a \x01(\tB\x06\x8a\xb5\x18\x02\x18\x10R\n" +
+	"postalCode\x12)\n" +
+	"\fcountry_code\x18\b \x01(\tB\x06\x8a\xb5\x18\x02\x18\x02R\vcountryCode\x12&\n" +
+	"\auser_id\x18\t \x01(\x03B\r\x8a\xb5\x18\tA\x00\x00\x00\x00\x00\x00\x00\x00R\x06userId\"o\n" +
+	"\x17ExportAddressesResponse\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\"{\n" +
+	"\x15MergeAddressesRequest\x12-\n" +
+	"\vsurvivor_id\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\b\x01*\x04uuidR\n" +
+	"survivorId\x123\n" +
+	"\rduplicate_ids\x18\x02 \x03(\tB\x0e\x8a\xb5\x18\n" +
+	"\b\x01*\x04uuidPdR\fduplicateIds2\x82\a\n" +
 	"\vUserService\x12C\n" +
 	"\n" +
 	"CreateUser\x12\x15.pb.CreateUserRequest\x1a\b.pb.User\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/users\x127\n" +
 	"\aGetUser\x12\n" +
-	".pb.UserId\x1a\b.pb.User\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/users/{id}\x12H\n" +
+	".pb.UserId\x1a\b.pb.User\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/users/{id}\x12]\n" +
 	"\n" +
-	"UpdateUser\x12\x15.pb.UpdateUserRequest\x1a\b.pb.User\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\x1a\x0e/v1/users/{id}\x12;\n" +
+	"UpdateUser\x12\x15.pb.UpdateUserRequest\x1a\b.pb.User\".\x82\xd3\xe4\x93\x02(:\x01*Z\x13:\x01*2\x0e/v1/users/{id}\x1a\x0e/v1/users/{id}\x12;\n" +
 	"\n" +
 	"DeleteUser\x12\n" +
-	".pb.UserId\x1a\t.pb.Empty\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/v1/users/{id}\x12?\n" +
-	"\tListUsers\x12\t.pb.Empty\x1a\x14.pb.UserListResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/users\x12B\n" +
-	"\x05Login\x12\x10.pb.LoginRequest\x1a\x11.pb.LoginResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/login2\xc5\x03\n" +
+	".pb.UserId\x1a\t.pb.Empty\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/v1/users/{id}\x12J\n" +
+	"\tListUsers\x12\x14.pb.ListUsersRequest\x1a\x14.pb.UserListResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/users\x12B\n" +
+	"\x05Login\x12\x10.pb.LoginRequest\x1a\x11.pb.LoginResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/login\x12X\n" +
+	"\fRefreshToken\x12\x17.pb.RefreshTokenRequest\x1a\x11.pb.LoginResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/token/refresh\x12=\n" +
+	"\x06Logout\x12\x11.pb.LogoutRequest\x1a\t.pb.Empty\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/v1/logout\x12I\n" +
+	"\x11RevokeAllSessions\x12\t.pb.Empty\x1a\t.pb.Empty\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/sessions/revoke\x12L\n" +
+	"\tGrantRole\x12\x0f.pb.RoleRequest\x1a\b.pb.User\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/users/{user_id}/roles\x12Q\n" +
+	"\n" +
+	"RevokeRole\x12\x0f.pb.RoleRequest\x1a\b.pb.User\"(\x82\xd3\xe4\x93\x02\"* /v1/users/{user_id}/roles/{role}\x12D\n" +
+	"\n" +
+	"UnlockUser\x12\n" +
+	".pb.UserId\x1a\b.pb.User\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/users/{id}/unlock2\xe8\v\n" +
 	"\x0eAddressService\x12X\n" +
 	"\rCreateAddress\x12\x18.pb.CreateAddressRequest\x1a\x13.pb.AddressResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/addresses\x12L\n" +
 	"\n" +
-	"GetAddress\x12\r.pb.AddressId\x1a\x13.pb.AddressResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/addresses/{id}\x12]\n" +
-	"\rUpdateAddress\x12\x18.pb.UpdateAddressRequest\x1a\x13.pb.AddressResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/addresses/{id}\x12U\n" +
+	"GetAddress\x12\r.pb.AddressId\x1a\x13.pb.AddressResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/addresses/{id}\x12v\n" +
+	"\rUpdateAddress\x12\x18.pb.UpdateAddressRequest\x1a\x13.pb.AddressResponse\"6\x82\xd3\xe4\x93\x020:\x01*Z\x17:\x01*2\x12/v1/addresses/{id}\x1a\x12/v1/addresses/{id}\x12U\n" +
 	"\rDeleteAddress\x12\r.pb.AddressId\x1a\x19.pb.DeleteAddressResponse\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/v1/addresses/{id}\x12U\n" +
-	"\vListAddress\x12\x16.pb.AddressListRequest\x1a\x17.pb.AddressListResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/addressesB$Z\"github.com/imimran/go-grpc-auth/pbb\x06proto3"
+	"\vListAddress\x12\x16.pb.AddressListRequest\x1a\x17.pb.AddressListResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/addresses\x12q\n" +
+	"\x11ListUserAddresses\x12\x1c.pb.ListUserAddressesRequest\x1a\x17.pb.AddressListResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/users/{user_id}/addresses\x12\x80\x01\n" +
+	"\x15SearchAddressesNearby\x12 .pb.SearchAddressesNearbyRequest\x1a!.pb.SearchAddressesNearbyResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/addresses:searchNearby\x12m\n" +
+	"\x0eReverseGeocode\x12\x19.pb.ReverseGeocodeRequest\x1a\x1a.pb.ReverseGeocodeResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/addresses:reverseGeocode\x12\x81\x01\n" +
+	"\x1aListAddressesInBoundingBox\x12%.pb.ListAddressesInBoundingBoxRequest\x1a\x17.pb.AddressListResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/addresses:inBoundingBox\x12x\n" +
+	"\x16ListAddressesInPolygon\x12!.pb.ListAddressesInPolygonRequest\x1a\x17.pb.AddressListResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/addresses:inPolygon\x12m\n" +
+	"\x0fImportAddresses\x12\x1a.pb.ImportAddressesRequest\x1a\x1b.pb.ImportAddressesResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/addresses:import(\x01\x12L\n" +
+	"\x0fExportAddresses\x12\x1a.pb.ExportAddressesRequest\x1a\x1b.pb.ExportAddressesResponse0\x01\x12\x85\x01\n" +
+	"\x16FindDuplicateAddresses\x12!.pb.FindDuplicateAddressesRequest\x1a\".pb.FindDuplicateAddressesResponse\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/addresses:findDuplicates\x12`\n" +
+	"\x0eMergeAddresses\x12\x19.pb.MergeAddressesRequest\x1a\x13.pb.AddressResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/addresses:mergeB$Z\"github.com/imimran/go-grpc-auth/pbb\x06proto3"
 
 var (
 	file_proto_protobuf_proto_rawDescOnce sync.Once
@@ -1154,62 +3257,140 @@ func file_proto_protobuf_proto_rawDescGZIP() []byte {
 	return file_proto_protobuf_proto_rawDescData
 }
 
-var file_proto_protobuf_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_proto_protobuf_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_proto_protobuf_proto_goTypes = []any{
-	(*Empty)(nil),                 // 0: pb.Empty
-	(*Coordinates)(nil),           // 1: pb.Coordinates
-	(*UserId)(nil),                // 2: pb.UserId
-	(*AddressId)(nil),             // 3: pb.AddressId
-	(*User)(nil),                  // 4: pb.User
-	(*CreateUserRequest)(nil),     // 5: pb.CreateUserRequest
-	(*UpdateUserRequest)(nil),     // 6: pb.UpdateUserRequest
-	(*LoginRequest)(nil),          // 7: pb.LoginRequest
-	(*LoginResponse)(nil),         // 8: pb.LoginResponse
-	(*UserListResponse)(nil),      // 9: pb.UserListResponse
-	(*Address)(nil),               // 10: pb.Address
-	(*CreateAddressRequest)(nil),  // 11: pb.CreateAddressRequest
-	(*UpdateAddressRequest)(nil),  // 12: pb.UpdateAddressRequest
-	(*AddressListRequest)(nil),    // 13: pb.AddressListRequest
-	(*AddressListData)(nil),       // 14: pb.AddressListData
-	(*AddressResponse)(nil),       // 15: pb.AddressResponse
-	(*DeleteAddressResponse)(nil), // 16: pb.DeleteAddressResponse
-	(*AddressListResponse)(nil),   // 17: pb.AddressListResponse
+	(*Empty)(nil),                             // 0: pb.Empty
+	(*Coordinates)(nil),                       // 1: pb.Coordinates
+	(*UserId)(nil),                            // 2: pb.UserId
+	(*AddressId)(nil),                         // 3: pb.AddressId
+	(*User)(nil),                              // 4: pb.User
+	(*CreateUserRequest)(nil),                 // 5: pb.CreateUserRequest
+	(*UpdateUserRequest)(nil),                 // 6: pb.UpdateUserRequest
+	(*LoginRequest)(nil),                      // 7: pb.LoginRequest
+	(*LoginResponse)(nil),                     // 8: pb.LoginResponse
+	(*RefreshTokenRequest)(nil),               // 9: pb.RefreshTokenRequest
+	(*RoleRequest)(nil),                       // 10: pb.RoleRequest
+	(*LogoutRequest)(nil),                     // 11: pb.LogoutRequest
+	(*ListUsersRequest)(nil),                  // 12: pb.ListUsersRequest
+	(*UserListResponse)(nil),                  // 13: pb.UserListResponse
+	(*Address)(nil),                           // 14: pb.Address
+	(*AddressComponents)(nil),                 // 15: pb.AddressComponents
+	(*CreateAddressRequest)(nil),              // 16: pb.CreateAddressRequest
+	(*UpdateAddressRequest)(nil),              // 17: pb.UpdateAddressRequest
+	(*AddressListRequest)(nil),                // 18: pb.AddressListRequest
+	(*ListUserAddressesRequest)(nil),          // 19: pb.ListUserAddressesRequest
+	(*SearchAddressesNearbyRequest)(nil),      // 20: pb.SearchAddressesNearbyRequest
+	(*NearbyAddress)(nil),                     // 21: pb.NearbyAddress
+	(*SearchAddressesNearbyResponse)(nil),     // 22: pb.SearchAddressesNearbyResponse
+	(*ReverseGeocodeRequest)(nil),             // 23: pb.ReverseGeocodeRequest
+	(*ReverseGeocodeResponse)(nil),            // 24: pb.ReverseGeocodeResponse
+	(*AddressListData)(nil),                   // 25: pb.AddressListData
+	(*AddressResponse)(nil),                   // 26: pb.AddressResponse
+	(*DeleteAddressResponse)(nil),             // 27: pb.DeleteAddressResponse
+	(*AddressListResponse)(nil),               // 28: pb.AddressListResponse
+	(*ListAddressesInBoundingBoxRequest)(nil), // 29: pb.ListAddressesInBoundingBoxRequest
+	(*ListAddressesInPolygonRequest)(nil),     // 30: pb.ListAddressesInPolygonRequest
+	(*PolygonPoints)(nil),                     // 31: pb.PolygonPoints
+	(*FindDuplicateAddressesRequest)(nil),     // 32: pb.FindDuplicateAddressesRequest
+	(*DuplicateCluster)(nil),                  // 33: pb.DuplicateCluster
+	(*FindDuplicateAddressesResponse)(nil),    // 34: pb.FindDuplicateAddressesResponse
+	(*ImportAddressesRequest)(nil),            // 35: pb.ImportAddressesRequest
+	(*ImportOptions)(nil),                     // 36: pb.ImportOptions
+	(*ImportRowResult)(nil),                   // 37: pb.ImportRowResult
+	(*ImportAddressesResponse)(nil),           // 38: pb.ImportAddressesResponse
+	(*ExportAddressesRequest)(nil),            // 39: pb.ExportAddressesRequest
+	(*ExportAddressesResponse)(nil),           // 40: pb.ExportAddressesResponse
+	(*MergeAddressesRequest)(nil),             // 41: pb.MergeAddressesRequest
+	(*timestamppb.Timestamp)(nil),             // 42: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),             // 43: google.protobuf.FieldMask
 }
 var file_proto_protobuf_proto_depIdxs = []int32{
-	4,  // 0: pb.UserListResponse.users:type_name -> pb.User
-	1,  // 1: pb.Address.coordinates:type_name -> pb.Coordinates
-	1,  // 2: pb.CreateAddressRequest.coordinates:type_name -> pb.Coordinates
-	1,  // 3: pb.UpdateAddressRequest.coordinates:type_name -> pb.Coordinates
-	10, // 4: pb.AddressListData.addresses:type_name -> pb.Address
-	10, // 5: pb.AddressResponse.data:type_name -> pb.Address
-	14, // 6: pb.AddressListResponse.data:type_name -> pb.AddressListData
-	5,  // 7: pb.UserService.CreateUser:input_type -> pb.CreateUserRequest
-	2,  // 8: pb.UserService.GetUser:input_type -> pb.UserId
-	6,  // 9: pb.UserService.UpdateUser:input_type -> pb.UpdateUserRequest
-	2,  // 10: pb.UserService.DeleteUser:input_type -> pb.UserId
-	0,  // 11: pb.UserService.ListUsers:input_type -> pb.Empty
-	7,  // 12: pb.UserService.Login:input_type -> pb.LoginRequest
-	11, // 13: pb.AddressService.CreateAddress:input_type -> pb.CreateAddressRequest
-	3,  // 14: pb.AddressService.GetAddress:input_type -> pb.AddressId
-	12, // 15: pb.AddressService.UpdateAddress:input_type -> pb.UpdateAddressRequest
-	3,  // 16: pb.AddressService.DeleteAddress:input_type -> pb.AddressId
-	13, // 17: pb.AddressService.ListAddress:input_type -> pb.AddressListRequest
-	4,  // 18: pb.UserService.CreateUser:output_type -> pb.User
-	4,  // 19: pb.UserService.GetUser:output_type -> pb.User
-	4,  // 20: pb.UserService.UpdateUser:output_type -> pb.User
-	0,  // 21: pb.UserService.DeleteUser:output_type -> pb.Empty
-	9,  // 22: pb.UserService.ListUsers:output_type -> pb.UserListResponse
-	8,  // 23: pb.UserService.Login:output_type -> pb.LoginResponse
-	15, // 24: pb.AddressService.CreateAddress:output_type -> pb.AddressResponse
-	15, // 25: pb.AddressService.GetAddress:output_type -> pb.AddressResponse
-	15, // 26: pb.AddressService.UpdateAddress:output_type -> pb.AddressResponse
-	16, // 27: pb.AddressService.DeleteAddress:output_type -> pb.DeleteAddressResponse
-	17, // 28: pb.AddressService.ListAddress:output_type -> pb.AddressListResponse
-	18, // [18:29] is the sub-list for method output_type
-	7,  // [7:18] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	42, // 0: pb.User.created_at:type_name -> google.protobuf.Timestamp
+	42, // 1: pb.User.locked_until:type_name -> google.protobuf.Timestamp
+	43, // 2: pb.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	42, // 3: pb.ListUsersRequest.created_after:type_name -> google.protobuf.Timestamp
+	42, // 4: pb.ListUsersRequest.created_before:type_name -> google.protobuf.Timestamp
+	4,  // 5: pb.UserListResponse.users:type_name -> pb.User
+	1,  // 6: pb.Address.coordinates:type_name -> pb.Coordinates
+	15, // 7: pb.Address.components:type_name -> pb.AddressComponents
+	1,  // 8: pb.CreateAddressRequest.coordinates:type_name -> pb.Coordinates
+	15, // 9: pb.CreateAddressRequest.components:type_name -> pb.AddressComponents
+	1,  // 10: pb.UpdateAddressRequest.coordinates:type_name -> pb.Coordinates
+	15, // 11: pb.UpdateAddressRequest.components:type_name -> pb.AddressComponents
+	43, // 12: pb.UpdateAddressRequest.update_mask:type_name -> google.protobuf.FieldMask
+	1,  // 13: pb.SearchAddressesNearbyRequest.center:type_name -> pb.Coordinates
+	14, // 14: pb.NearbyAddress.address:type_name -> pb.Address
+	21, // 15: pb.SearchAddressesNearbyResponse.results:type_name -> pb.NearbyAddress
+	1,  // 16: pb.ReverseGeocodeRequest.point:type_name -> pb.Coordinates
+	14, // 17: pb.ReverseGeocodeResponse.address:type_name -> pb.Address
+	14, // 18: pb.AddressListData.addresses:type_name -> pb.Address
+	14, // 19: pb.AddressResponse.data:type_name -> pb.Address
+	25, // 20: pb.AddressListResponse.data:type_name -> pb.AddressListData
+	1,  // 21: pb.ListAddressesInBoundingBoxRequest.south_west:type_name -> pb.Coordinates
+	1,  // 22: pb.ListAddressesInBoundingBoxRequest.north_east:type_name -> pb.Coordinates
+	31, // 23: pb.ListAddressesInPolygonRequest.points:type_name -> pb.PolygonPoints
+	1,  // 24: pb.PolygonPoints.points:type_name -> pb.Coordinates
+	14, // 25: pb.DuplicateCluster.addresses:type_name -> pb.Address
+	33, // 26: pb.FindDuplicateAddressesResponse.clusters:type_name -> pb.DuplicateCluster
+	36, // 27: pb.ImportAddressesRequest.options:type_name -> pb.ImportOptions
+	37, // 28: pb.ImportAddressesResponse.rows:type_name -> pb.ImportRowResult
+	5,  // 29: pb.UserService.CreateUser:input_type -> pb.CreateUserRequest
+	2,  // 30: pb.UserService.GetUser:input_type -> pb.UserId
+	6,  // 31: pb.UserService.UpdateUser:input_type -> pb.UpdateUserRequest
+	2,  // 32: pb.UserService.DeleteUser:input_type -> pb.UserId
+	12, // 33: pb.UserService.ListUsers:input_type -> pb.ListUsersRequest
+	7,  // 34: pb.UserService.Login:input_type -> pb.LoginRequest
+	9,  // 35: pb.UserService.RefreshToken:input_type -> pb.RefreshTokenRequest
+	11, // 36: pb.UserService.Logout:input_type -> pb.LogoutRequest
+	0,  // 37: pb.UserService.RevokeAllSessions:input_type -> pb.Empty
+	10, // 38: pb.UserService.GrantRole:input_type -> pb.RoleRequest
+	10, // 39: pb.UserService.RevokeRole:input_type -> pb.RoleRequest
+	2,  // 40: pb.UserService.UnlockUser:input_type -> pb.UserId
+	16, // 41: pb.AddressService.CreateAddress:input_type -> pb.CreateAddressRequest
+	3,  // 42: pb.AddressService.GetAddress:input_type -> pb.AddressId
+	17, // 43: pb.AddressService.UpdateAddress:input_type -> pb.UpdateAddressRequest
+	3,  // 44: pb.AddressService.DeleteAddress:input_type -> pb.AddressId
+	18, // 45: pb.AddressService.ListAddress:input_type -> pb.AddressListRequest
+	19, // 46: pb.AddressService.ListUserAddresses:input_type -> pb.ListUserAddressesRequest
+	20, // 47: pb.AddressService.SearchAddressesNearby:input_type -> pb.SearchAddressesNearbyRequest
+	23, // 48: pb.AddressService.ReverseGeocode:input_type -> pb.ReverseGeocodeRequest
+	29, // 49: pb.AddressService.ListAddressesInBoundingBox:input_type -> pb.ListAddressesInBoundingBoxRequest
+	30, // 50: pb.AddressService.ListAddressesInPolygon:input_type -> pb.ListAddressesInPolygonRequest
+	35, // 51: pb.AddressService.ImportAddresses:input_type -> pb.ImportAddressesRequest
+	39, // 52: pb.AddressService.ExportAddresses:input_type -> pb.ExportAddressesRequest
+	32, // 53: pb.AddressService.FindDuplicateAddresses:input_type -> pb.FindDuplicateAddressesRequest
+	41, // 54: pb.AddressService.MergeAddresses:input_type -> pb.MergeAddressesRequest
+	4,  // 55: pb.UserService.CreateUser:output_type -> pb.User
+	4,  // 56: pb.UserService.GetUser:output_type -> pb.User
+	4,  // 57: pb.UserService.UpdateUser:output_type -> pb.User
+	0,  // 58: pb.UserService.DeleteUser:output_type -> pb.Empty
+	13, // 59: pb.UserService.ListUsers:output_type -> pb.UserListResponse
+	8,  // 60: pb.UserService.Login:output_type -> pb.LoginResponse
+	8,  // 61: pb.UserService.RefreshToken:output_type -> pb.LoginResponse
+	0,  // 62: pb.UserService.Logout:output_type -> pb.Empty
+	0,  // 63: pb.UserService.RevokeAllSessions:output_type -> pb.Empty
+	4,  // 64: pb.UserService.GrantRole:output_type -> pb.User
+	4,  // 65: pb.UserService.RevokeRole:output_type -> pb.User
+	4,  // 66: pb.UserService.UnlockUser:output_type -> pb.User
+	26, // 67: pb.AddressService.CreateAddress:output_type -> pb.AddressResponse
+	26, // 68: pb.AddressService.GetAddress:output_type -> pb.AddressResponse
+	26, // 69: pb.AddressService.UpdateAddress:output_type -> pb.AddressResponse
+	27, // 70: pb.AddressService.DeleteAddress:output_type -> pb.DeleteAddressResponse
+	28, // 71: pb.AddressService.ListAddress:output_type -> pb.AddressListResponse
+	28, // 72: pb.AddressService.ListUserAddresses:output_type -> pb.AddressListResponse
+	22, // 73: pb.AddressService.SearchAddressesNearby:output_type -> pb.SearchAddressesNearbyResponse
+	24, // 74: pb.AddressService.ReverseGeocode:output_type -> pb.ReverseGeocodeResponse
+	28, // 75: pb.AddressService.ListAddressesInBoundingBox:output_type -> pb.AddressListResponse
+	28, // 76: pb.AddressService.ListAddressesInPolygon:output_type -> pb.AddressListResponse
+	38, // 77: pb.AddressService.ImportAddresses:output_type -> pb.ImportAddressesResponse
+	40, // 78: pb.AddressService.ExportAddresses:output_type -> pb.ExportAddressesResponse
+	34, // 79: pb.AddressService.FindDuplicateAddresses:output_type -> pb.FindDuplicateAddressesResponse
+	26, // 80: pb.AddressService.MergeAddresses:output_type -> pb.AddressResponse
+	55, // [55:81] is the sub-list for method output_type
+	29, // [29:55] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_proto_protobuf_proto_init() }
@@ -1217,13 +3398,24 @@ func file_proto_protobuf_proto_init() {
 	if File_proto_protobuf_proto != nil {
 		return
 	}
+	file_proto_validate_proto_init()
+	file_proto_protobuf_proto_msgTypes[12].OneofWrappers = []any{}
+	file_proto_protobuf_proto_msgTypes[18].OneofWrappers = []any{}
+	file_proto_protobuf_proto_msgTypes[30].OneofWrappers = []any{
+		(*ListAddressesInPolygonRequest_Geojson)(nil),
+		(*ListAddressesInPolygonRequest_Points)(nil),
+	}
+	file_proto_protobuf_proto_msgTypes[35].OneofWrappers = []any{
+		(*ImportAddressesRequest_Options)(nil),
+		(*ImportAddressesRequest_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_protobuf_proto_rawDesc), len(file_proto_protobuf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -21,12 +21,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_CreateUser_FullMethodName = "/pb.UserService/CreateUser"
-	UserService_GetUser_FullMethodName    = "/pb.UserService/GetUser"
-	UserService_UpdateUser_FullMethodName = "/pb.UserService/UpdateUser"
-	UserService_DeleteUser_FullMethodName = "/pb.UserService/DeleteUser"
-	UserService_ListUsers_FullMethodName  = "/pb.UserService/ListUsers"
-	UserService_Login_FullMethodName      = "/pb.UserService/Login"
+	UserService_CreateUser_FullMethodName        = "/pb.UserService/CreateUser"
+	UserService_GetUser_FullMethodName           = "/pb.UserService/GetUser"
+	UserService_UpdateUser_FullMethodName        = "/pb.UserService/UpdateUser"
+	UserService_DeleteUser_FullMethodName        = "/pb.UserService/DeleteUser"
+	UserService_ListUsers_FullMethodName         = "/pb.UserService/ListUsers"
+	UserService_Login_FullMethodName             = "/pb.UserService/Login"
+	UserService_RefreshToken_FullMethodName      = "/pb.UserService/RefreshToken"
+	UserService_Logout_FullMethodName            = "/pb.UserService/Logout"
+	UserService_RevokeAllSessions_FullMethodName = "/pb.UserService/RevokeAllSessions"
+	UserService_GrantRole_FullMethodName         = "/pb.UserService/GrantRole"
+	UserService_RevokeRole_FullMethodName        = "/pb.UserService/RevokeRole"
+	UserService_UnlockUser_FullMethodName        = "/pb.UserService/UnlockUser"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -37,8 +43,16 @@ type UserServiceClient interface {
 	GetUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*User, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error)
 	DeleteUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Empty, error)
-	ListUsers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UserListResponse, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*UserListResponse, error)
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*Empty, error)
+	RevokeAllSessions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// Admin only
+	GrantRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*User, error)
+	RevokeRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*User, error)
+	// Clears failed login attempts and any lockout
+	UnlockUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*User, error)
 }
 
 type userServiceClient struct {
@@ -89,7 +103,7 @@ func (c *userServiceClient) DeleteUser(ctx context.Context, in *UserId, opts ...
 	return out, nil
 }
 
-func (c *userServiceClient) ListUsers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UserListResponse, error) {
+func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*UserListResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UserListResponse)
 	err := c.cc.Invoke(ctx, UserService_ListUsers_FullMethodName, in, out, cOpts...)
@@ -109,6 +123,66 @@ func (c *userServiceClient) Login(ctx context.Context, in *LoginRequest, opts ..
 	return out, nil
 }
 
+func (c *userServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, UserService_RefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, UserService_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeAllSessions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, UserService_RevokeAllSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GrantRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_GrantRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_RevokeRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UnlockUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_UnlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -117,8 +191,16 @@ type UserServiceServer interface {
 	GetUser(context.Context, *UserId) (*User, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*User, error)
 	DeleteUser(context.Context, *UserId) (*Empty, error)
-	ListUsers(context.Context, *Empty) (*UserListResponse, error)
+	ListUsers(context.Context, *ListUsersRequest) (*UserListResponse, error)
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	RefreshToken(context.Context, *RefreshTokenRequest) (*LoginResponse, error)
+	Logout(context.Context, *LogoutRequest) (*Empty, error)
+	RevokeAllSessions(context.Context, *Empty) (*Empty, error)
+	// Admin only
+	GrantRole(context.Context, *RoleRequest) (*User, error)
+	RevokeRole(context.Context, *RoleRequest) (*User, error)
+	// Clears failed login attempts and any lockout
+	UnlockUser(context.Context, *UserId) (*User, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -141,12 +223,30 @@ func (UnimplementedUserServiceServer) UpdateUser(context.Context, *UpdateUserReq
 func (UnimplementedUserServiceServer) DeleteUser(context.Context, *UserId) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
 }
-func (UnimplementedUserServiceServer) ListUsers(context.Context, *Empty) (*UserListResponse, error) {
+func (UnimplementedUserServiceServer) ListUsers(context.Context, *ListUsersRequest) (*UserListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
 }
 func (UnimplementedUserServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
 }
+func (UnimplementedUserServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedUserServiceServer) Logout(context.Context, *LogoutRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeAllSessions(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAllSessions not implemented")
+}
+func (UnimplementedUserServiceServer) GrantRole(context.Context, *RoleRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantRole not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeRole(context.Context, *RoleRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeRole not implemented")
+}
+func (UnimplementedUserServiceServer) UnlockUser(context.Context, *UserId) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockUser not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -241,7 +341,7 @@ func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec f
 }
 
 func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(ListUsersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -253,7 +353,7 @@ func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec fu
 		FullMethod: UserService_ListUsers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).ListUsers(ctx, req.(*Empty))
+		return srv.(UserServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -276,6 +376,114 @@ func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokeAllSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeAllSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RevokeAllSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeAllSessions(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GrantRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GrantRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GrantRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GrantRole(ctx, req.(*RoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokeRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RevokeRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeRole(ctx, req.(*RoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UnlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UnlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UnlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UnlockUser(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -307,17 +515,50 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Login",
 			Handler:    _UserService_Login_Handler,
 		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _UserService_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _UserService_Logout_Handler,
+		},
+		{
+			MethodName: "RevokeAllSessions",
+			Handler:    _UserService_RevokeAllSessions_Handler,
+		},
+		{
+			MethodName: "GrantRole",
+			Handler:    _UserService_GrantRole_Handler,
+		},
+		{
+			MethodName: "RevokeRole",
+			Handler:    _UserService_RevokeRole_Handler,
+		},
+		{
+			MethodName: "UnlockUser",
+			Handler:    _UserService_UnlockUser_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/protobuf.proto",
 }
 
 const (
-	AddressService_CreateAddress_FullMethodName = "/pb.AddressService/CreateAddress"
-	AddressService_GetAddress_FullMethodName    = "/pb.AddressService/GetAddress"
-	AddressService_UpdateAddress_FullMethodName = "/pb.AddressService/UpdateAddress"
-	AddressService_DeleteAddress_FullMethodName = "/pb.AddressService/DeleteAddress"
-	AddressService_ListAddress_FullMethodName   = "/pb.AddressService/ListAddress"
+	AddressService_CreateAddress_FullMethodName              = "/pb.AddressService/CreateAddress"
+	AddressService_GetAddress_FullMethodName                 = "/pb.AddressService/GetAddress"
+	AddressService_UpdateAddress_FullMethodName              = "/pb.AddressService/UpdateAddress"
+	AddressService_DeleteAddress_FullMethodName              = "/pb.AddressService/DeleteAddress"
+	AddressService_ListAddress_FullMethodName                = "/pb.AddressService/ListAddress"
+	AddressService_ListUserAddresses_FullMethodName          = "/pb.AddressService/ListUserAddresses"
+	AddressService_SearchAddressesNearby_FullMethodName      = "/pb.AddressService/SearchAddressesNearby"
+	AddressService_ReverseGeocode_FullMethodName             = "/pb.AddressService/ReverseGeocode"
+	AddressService_ListAddressesInBoundingBox_FullMethodName = "/pb.AddressService/ListAddressesInBoundingBox"
+	AddressService_ListAddressesInPolygon_FullMethodName     = "/pb.AddressService/ListAddressesInPolygon"
+	AddressService_ImportAddresses_FullMethodName            = "/pb.AddressService/ImportAddresses"
+	AddressService_ExportAddresses_FullMethodName            = "/pb.AddressService/ExportAddresses"
+	AddressService_FindDuplicateAddresses_FullMethodName     = "/pb.AddressService/FindDuplicateAddresses"
+	AddressService_MergeAddresses_FullMethodName             = "/pb.AddressService/MergeAddresses"
 )
 
 // AddressServiceClient is the client API for AddressService service.
@@ -329,6 +570,22 @@ type AddressServiceClient interface {
 	UpdateAddress(ctx context.Context, in *UpdateAddressRequest, opts ...grpc.CallOption) (*AddressResponse, error)
 	DeleteAddress(ctx context.Context, in *AddressId, opts ...grpc.CallOption) (*DeleteAddressResponse, error)
 	ListAddress(ctx context.Context, in *AddressListRequest, opts ...grpc.CallOption) (*AddressListResponse, error)
+	// Admin only
+	ListUserAddresses(ctx context.Context, in *ListUserAddressesRequest, opts ...grpc.CallOption) (*AddressListResponse, error)
+	SearchAddressesNearby(ctx context.Context, in *SearchAddressesNearbyRequest, opts ...grpc.CallOption) (*SearchAddressesNearbyResponse, error)
+	ReverseGeocode(ctx context.Context, in *ReverseGeocodeRequest, opts ...grpc.CallOption) (*ReverseGeocodeResponse, error)
+	ListAddressesInBoundingBox(ctx context.Context, in *ListAddressesInBoundingBoxRequest, opts ...grpc.CallOption) (*AddressListResponse, error)
+	ListAddressesInPolygon(ctx context.Context, in *ListAddressesInPolygonRequest, opts ...grpc.CallOption) (*AddressListResponse, error)
+	// Client streaming: options first, then the CSV / GeoJSON file in chunks
+	ImportAddresses(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportAddressesRequest, ImportAddressesResponse], error)
+	// Server streaming: the encoded file in chunks. Over HTTP it is served as
+	// a plain download from GET /v1/addresses:export rather than the gateway's
+	// JSON stream, so it has no google.api.http binding.
+	ExportAddresses(ctx context.Context, in *ExportAddressesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportAddressesResponse], error)
+	// Admin only
+	FindDuplicateAddresses(ctx context.Context, in *FindDuplicateAddressesRequest, opts ...grpc.CallOption) (*FindDuplicateAddressesResponse, error)
+	// Admin only
+	MergeAddresses(ctx context.Context, in *MergeAddressesRequest, opts ...grpc.CallOption) (*AddressResponse, error)
 }
 
 type addressServiceClient struct {
@@ -389,6 +646,108 @@ func (c *addressServiceClient) ListAddress(ctx context.Context, in *AddressListR
 	return out, nil
 }
 
+func (c *addressServiceClient) ListUserAddresses(ctx context.Context, in *ListUserAddressesRequest, opts ...grpc.CallOption) (*AddressListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddressListResponse)
+	err := c.cc.Invoke(ctx, AddressService_ListUserAddresses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *addressServiceClient) SearchAddressesNearby(ctx context.Context, in *SearchAddressesNearbyRequest, opts ...grpc.CallOption) (*SearchAddressesNearbyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchAddressesNearbyResponse)
+	err := c.cc.Invoke(ctx, AddressService_SearchAddressesNearby_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *addressServiceClient) ReverseGeocode(ctx context.Context, in *ReverseGeocodeRequest, opts ...grpc.CallOption) (*ReverseGeocodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReverseGeocodeResponse)
+	err := c.cc.Invoke(ctx, AddressService_ReverseGeocode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *addressServiceClient) ListAddressesInBoundingBox(ctx context.Context, in *ListAddressesInBoundingBoxRequest, opts ...grpc.CallOption) (*AddressListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddressListResponse)
+	err := c.cc.Invoke(ctx, AddressService_ListAddressesInBoundingBox_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *addressServiceClient) ListAddressesInPolygon(ctx context.Context, in *ListAddressesInPolygonRequest, opts ...grpc.CallOption) (*AddressListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddressListResponse)
+	err := c.cc.Invoke(ctx, AddressService_ListAddressesInPolygon_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *addressServiceClient) ImportAddresses(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportAddressesRequest, ImportAddressesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AddressService_ServiceDesc.Streams[0], AddressService_ImportAddresses_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportAddressesRequest, ImportAddressesResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AddressService_ImportAddressesClient = grpc.ClientStreamingClient[ImportAddressesRequest, ImportAddressesResponse]
+
+func (c *addressServiceClient) ExportAddresses(ctx context.Context, in *ExportAddressesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportAddressesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AddressService_ServiceDesc.Streams[1], AddressService_ExportAddresses_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportAddressesRequest, ExportAddressesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AddressService_ExportAddressesClient = grpc.ServerStreamingClient[ExportAddressesResponse]
+
+func (c *addressServiceClient) FindDuplicateAddresses(ctx context.Context, in *FindDuplicateAddressesRequest, opts ...grpc.CallOption) (*FindDuplicateAddressesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindDuplicateAddressesResponse)
+	err := c.cc.Invoke(ctx, AddressService_FindDuplicateAddresses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *addressServiceClient) MergeAddresses(ctx context.Context, in *MergeAddressesRequest, opts ...grpc.CallOption) (*AddressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddressResponse)
+	err := c.cc.Invoke(ctx, AddressService_MergeAddresses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AddressServiceServer is the server API for AddressService service.
 // All implementations must embed UnimplementedAddressServiceServer
 // for forward compatibility.
@@ -398,6 +757,22 @@ type AddressServiceServer interface {
 	UpdateAddress(context.Context, *UpdateAddressRequest) (*AddressResponse, error)
 	DeleteAddress(context.Context, *AddressId) (*DeleteAddressResponse, error)
 	ListAddress(context.Context, *AddressListRequest) (*AddressListResponse, error)
+	// Admin only
+	ListUserAddresses(context.Context, *ListUserAddressesRequest) (*AddressListResponse, error)
+	SearchAddressesNearby(context.Context, *SearchAddressesNearbyRequest) (*SearchAddressesNearbyResponse, error)
+	ReverseGeocode(context.Context, *ReverseGeocodeRequest) (*ReverseGeocodeResponse, error)
+	ListAddressesInBoundingBox(context.Context, *ListAddressesInBoundingBoxRequest) (*AddressListResponse, error)
+	ListAddressesInPolygon(context.Context, *ListAddressesInPolygonRequest) (*AddressListResponse, error)
+	// Client streaming: options first, then the CSV / GeoJSON file in chunks
+	ImportAddresses(grpc.ClientStreamingServer[ImportAddressesRequest, ImportAddressesResponse]) error
+	// Server streaming: the encoded file in chunks. Over HTTP it is served as
+	// a plain download from GET /v1/addresses:export rather than the gateway's
+	// JSON stream, so it has no google.api.http binding.
+	ExportAddresses(*ExportAddressesRequest, grpc.ServerStreamingServer[ExportAddressesResponse]) error
+	// Admin only
+	FindDuplicateAddresses(context.Context, *FindDuplicateAddressesRequest) (*FindDuplicateAddressesResponse, error)
+	// Admin only
+	MergeAddresses(context.Context, *MergeAddressesRequest) (*AddressResponse, error)
 	mustEmbedUnimplementedAddressServiceServer()
 }
 
@@ -423,6 +798,33 @@ func (UnimplementedAddressServiceServer) DeleteAddress(context.Context, *Address
 func (UnimplementedAddressServiceServer) ListAddress(context.Context, *AddressListRequest) (*AddressListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAddress not implemented")
 }
+func (UnimplementedAddressServiceServer) ListUserAddresses(context.Context, *ListUserAddressesRequest) (*AddressListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserAddresses not implemented")
+}
+func (UnimplementedAddressServiceServer) SearchAddressesNearby(context.Context, *SearchAddressesNearbyRequest) (*SearchAddressesNearbyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchAddressesNearby not implemented")
+}
+func (UnimplementedAddressServiceServer) ReverseGeocode(context.Context, *ReverseGeocodeRequest) (*ReverseGeocodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReverseGeocode not implemented")
+}
+func (UnimplementedAddressServiceServer) ListAddressesInBoundingBox(context.Context, *ListAddressesInBoundingBoxRequest) (*AddressListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAddressesInBoundingBox not implemented")
+}
+func (UnimplementedAddressServiceServer) ListAddressesInPolygon(context.Context, *ListAddressesInPolygonRequest) (*AddressListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAddressesInPolygon not implemented")
+}
+func (UnimplementedAddressServiceServer) ImportAddresses(grpc.ClientStreamingServer[ImportAddressesRequest, ImportAddressesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportAddresses not implemented")
+}
+func (UnimplementedAddressServiceServer) ExportAddresses(*ExportAddressesRequest, grpc.ServerStreamingServer[ExportAddressesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAddresses not implemented")
+}
+func (UnimplementedAddressServiceServer) FindDuplicateAddresses(context.Context, *FindDuplicateAddressesRequest) (*FindDuplicateAddressesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindDuplicateAddresses not implemented")
+}
+func (UnimplementedAddressServiceServer) MergeAddresses(context.Context, *MergeAddressesRequest) (*AddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeAddresses not implemented")
+}
 func (UnimplementedAddressServiceServer) mustEmbedUnimplementedAddressServiceServer() {}
 func (UnimplementedAddressServiceServer) testEmbeddedByValue()                        {}
 
@@ -534,6 +936,150 @@ func _AddressService_ListAddress_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AddressService_ListUserAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressServiceServer).ListUserAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressService_ListUserAddresses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressServiceServer).ListUserAddresses(ctx, req.(*ListUserAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AddressService_SearchAddressesNearby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchAddressesNearbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressServiceServer).SearchAddressesNearby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressService_SearchAddressesNearby_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressServiceServer).SearchAddressesNearby(ctx, req.(*SearchAddressesNearbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AddressService_ReverseGeocode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseGeocodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressServiceServer).ReverseGeocode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressService_ReverseGeocode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressServiceServer).ReverseGeocode(ctx, req.(*ReverseGeocodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AddressService_ListAddressesInBoundingBox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAddressesInBoundingBoxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressServiceServer).ListAddressesInBoundingBox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressService_ListAddressesInBoundingBox_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressServiceServer).ListAddressesInBoundingBox(ctx, req.(*ListAddressesInBoundingBoxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AddressService_ListAddressesInPolygon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAddressesInPolygonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressServiceServer).ListAddressesInPolygon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressService_ListAddressesInPolygon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressServiceServer).ListAddressesInPolygon(ctx, req.(*ListAddressesInPolygonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AddressService_ImportAddresses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AddressServiceServer).ImportAddresses(&grpc.GenericServerStream[ImportAddressesRequest, ImportAddressesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AddressService_ImportAddressesServer = grpc.ClientStreamingServer[ImportAddressesRequest, ImportAddressesResponse]
+
+func _AddressService_ExportAddresses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportAddressesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AddressServiceServer).ExportAddresses(m, &grpc.GenericServerStream[ExportAddressesRequest, ExportAddressesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AddressService_ExportAddressesServer = grpc.ServerStreamingServer[ExportAddressesResponse]
+
+func _AddressService_FindDuplicateAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDuplicateAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressServiceServer).FindDuplicateAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressService_FindDuplicateAddresses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressServiceServer).FindDuplicateAddresses(ctx, req.(*FindDuplicateAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AddressService_MergeAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressServiceServer).MergeAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressService_MergeAddresses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressServiceServer).MergeAddresses(ctx, req.(*MergeAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AddressService_ServiceDesc is the grpc.ServiceDesc for AddressService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -561,7 +1107,46 @@ var AddressService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAddress",
 			Handler:    _AddressService_ListAddress_Handler,
 		},
+		{
+			MethodName: "ListUserAddresses",
+			Handler:    _AddressService_ListUserAddresses_Handler,
+		},
+		{
+			MethodName: "SearchAddressesNearby",
+			Handler:    _AddressService_SearchAddressesNearby_Handler,
+		},
+		{
+			MethodName: "ReverseGeocode",
+			Handler:    _AddressService_ReverseGeocode_Handler,
+		},
+		{
+			MethodName: "ListAddressesInBoundingBox",
+			Handler:    _AddressService_ListAddressesInBoundingBox_Handler,
+		},
+		{
+			MethodName: "ListAddressesInPolygon",
+			Handler:    _AddressService_ListAddressesInPolygon_Handler,
+		},
+		{
+			MethodName: "FindDuplicateAddresses",
+			Handler:    _AddressService_FindDuplicateAddresses_Handler,
+		},
+		{
+			MethodName: "MergeAddresses",
+			Handler:    _AddressService_MergeAddresses_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportAddresses",
+			Handler:       _AddressService_ImportAddresses_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportAddresses",
+			Handler:       _AddressService_ExportAddresses_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/protobuf.proto",
 }
@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.21.12
+// source: proto/validate.proto
+
+// Declarative request validation. Annotate a field with
+//   string email = 1 [(validate.rules) = {required: true, format: "email", max_len: 255}];
+// and the validate interceptor rejects requests that break a rule with
+// INVALID_ARGUMENT before the handler runs.
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FieldRules constrain one field. Only the rules that fit the field's type
+// are checked; on repeated fields they apply to every element. Empty strings
+// and unset optional fields are only checked by `required`.
+type FieldRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Strings and bytes must be non-empty, messages set, lists non-empty
+	Required bool `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`
+	// Strings, in characters
+	MinLen uint32 `protobuf:"varint,2,opt,name=min_len,json=minLen,proto3" json:"min_len,omitempty"`
+	MaxLen uint32 `protobuf:"varint,3,opt,name=max_len,json=maxLen,proto3" json:"max_len,omitempty"`
+	// Strings and bytes, in bytes
+	MaxBytes uint32 `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	// Strings: "email" or "uuid"
+	Format string `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`
+	// Strings: the allowed values
+	In []string `protobuf:"bytes,6,rep,name=in,proto3" json:"in,omitempty"`
+	// Numbers
+	Gt  *float64 `protobuf:"fixed64,7,opt,name=gt,proto3,oneof" json:"gt,omitempty"`
+	Gte *float64 `protobuf:"fixed64,8,opt,name=gte,proto3,oneof" json:"gte,omitempty"`
+	Lte *float64 `protobuf:"fixed64,9,opt,name=lte,proto3,oneof" json:"lte,omitempty"`
+	// Repeated fields
+	MaxItems      uint32 `protobuf:"varint,10,opt,name=max_items,json=maxItems,proto3" json:"max_items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldRules) Reset() {
+	*x = FieldRules{}
+	mi := &file_proto_validate_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldRules) ProtoMessage() {}
+
+func (x *FieldRules) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_validate_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldRules.ProtoReflect.Descriptor instead.
+func (*FieldRules) Descriptor() ([]byte, []int) {
+	return file_proto_validate_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FieldRules) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *FieldRules) GetMinLen() uint32 {
+	if x != nil {
+		return x.MinLen
+	}
+	return 0
+}
+
+func (x *FieldRules) GetMaxLen() uint32 {
+	if x != nil {
+		return x.MaxLen
+	}
+	return 0
+}
+
+func (x *FieldRules) GetMaxBytes() uint32 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *FieldRules) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *FieldRules) GetIn() []string {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *FieldRules) GetGt() float64 {
+	if x != nil && x.Gt != nil {
+		return *x.Gt
+	}
+	return 0
+}
+
+func (x *FieldRules) GetGte() float64 {
+	if x != nil && x.Gte != nil {
+		return *x.Gte
+	}
+	return 0
+}
+
+func (x *FieldRules) GetLte() float64 {
+	if x != nil && x.Lte != nil {
+		return *x.Lte
+	}
+	return 0
+}
+
+func (x *FieldRules) GetMaxItems() uint32 {
+	if x != nil {
+		return x.MaxItems
+	}
+	return 0
+}
+
+var file_proto_validate_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldRules)(nil),
+		Field:         50001,
+		Name:          "validate.rules",
+		Tag:           "bytes,50001,opt,name=rules",
+		Filename:      "proto/validate.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional validate.FieldRules rules = 50001;
+	E_Rules = &file_proto_validate_proto_extTypes[0]
+)
+
+var File_proto_validate_proto protoreflect.FileDescriptor
+
+const file_proto_validate_proto_rawDesc = "" +
+	"\n" +
+	"\x14proto/validate.proto\x12\bvalidate\x1a google/protobuf/descriptor.proto\"\x96\x02\n" +
+	"\n" +
+	"FieldRules\x12\x1a\n" +
+	"\brequired\x18\x01 \x01(\bR\brequired\x12\x17\n" +
+	"\amin_len\x18\x02 \x01(\rR\x06minLen\x12\x17\n" +
+	"\amax_len\x18\x03 \x01(\rR\x06maxLen\x12\x1b\n" +
+	"\tmax_bytes\x18\x04 \x01(\rR\bmaxBytes\x12\x16\n" +
+	"\x06format\x18\x05 \x01(\tR\x06format\x12\x0e\n" +
+	"\x02in\x18\x06 \x03(\tR\x02in\x12\x13\n" +
+	"\x02gt\x18\a \x01(\x01H\x00R\x02gt\x88\x01\x01\x12\x15\n" +
+	"\x03gte\x18\b \x01(\x01H\x01R\x03gte\x88\x01\x01\x12\x15\n" +
+	"\x03lte\x18\t \x01(\x01H\x02R\x03lte\x88\x01\x01\x12\x1b\n" +
+	"\tmax_items\x18\n" +
+	" \x01(\rR\bmaxItemsB\x05\n" +
+	"\x03_gtB\x06\n" +
+	"\x04_gteB\x06\n" +
+	"\x04_lte:K\n" +
+	"\x05rules\x12\x1d.google.protobuf.FieldOptions\x18ц\x03 \x01(\v2\x14.validate.FieldRulesR\x05rulesB$Z\"github.com/imimran/go-grpc-auth/pbb\x06proto3"
+
+var (
+	file_proto_validate_proto_rawDescOnce sync.Once
+	file_proto_validate_proto_rawDescData []byte
+)
+
+func file_proto_validate_proto_rawDescGZIP() []byte {
+	file_proto_validate_proto_rawDescOnce.Do(func() {
+		file_proto_validate_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_validate_proto_rawDesc), len(file_proto_validate_proto_rawDesc)))
+	})
+	return file_proto_validate_proto_rawDescData
+}
+
+var file_proto_validate_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_proto_validate_proto_goTypes = []any{
+	(*FieldRules)(nil),                // 0: validate.FieldRules
+	(*descriptorpb.FieldOptions)(nil), // 1: google.protobuf.FieldOptions
+}
+var file_proto_validate_proto_depIdxs = []int32{
+	1, // 0: validate.rules:extendee -> google.protobuf.FieldOptions
+	0, // 1: validate.rules:type_name -> validate.FieldRules
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_proto_validate_proto_init() }
+func file_proto_validate_proto_init() {
+	if File_proto_validate_proto != nil {
+		return
+	}
+	file_proto_validate_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_validate_proto_rawDesc), len(file_proto_validate_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_validate_proto_goTypes,
+		DependencyIndexes: file_proto_validate_proto_depIdxs,
+		MessageInfos:      file_proto_validate_proto_msgTypes,
+		ExtensionInfos:    file_proto_validate_proto_extTypes,
+	}.Build()
+	File_proto_validate_proto = out.File
+	file_proto_validate_proto_goTypes = nil
+	file_proto_validate_proto_depIdxs = nil
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// forwardedForKey is the metadata the gateway fills from the request's
+// X-Forwarded-For header and remote address
+const forwardedForKey = "x-forwarded-for"
+
+// ProxyList holds the networks of proxies, including the gateway, whose
+// X-Forwarded-For is believed.
+type ProxyList []netip.Prefix
+
+// ParseProxies parses CIDRs ("10.0.0.0/8") and single addresses.
+func ParseProxies(values []string) (ProxyList, error) {
+	proxies := make(ProxyList, 0, len(values))
+	for _, v := range values {
+		if !strings.Contains(v, "/") {
+			addr, err := netip.ParseAddr(v)
+			if err != nil {
+				return nil, fmt.Errorf("trusted proxy %q: %w", v, err)
+			}
+			proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q: %w", v, err)
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+	return proxies, nil
+}
+
+func (p ProxyList) trusts(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the address of the client behind the call: the gRPC
+// peer, or, when the peer is a trusted proxy, the last address in
+// X-Forwarded-For that is not a trusted proxy itself. Entries left of it
+// could be forged by the client and are ignored. ok is false when the peer
+// is unknown.
+func (p ProxyList) ClientIP(ctx context.Context) (ip string, ok bool) {
+	pr, ok := peer.FromContext(ctx)
+	if !ok || pr.Addr == nil {
+		return "", false
+	}
+	client, err := addrOf(pr.Addr.String())
+	if err != nil {
+		return pr.Addr.String(), true
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	var hops []string
+	for _, v := range md.Get(forwardedForKey) {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0 && p.trusts(client); i-- {
+		hop, err := addrOf(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		client = hop
+	}
+	return client.Unmap().String(), true
+}
+
+// addrOf parses an IP with or without a port
+func addrOf(s string) (netip.Addr, error) {
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	return netip.ParseAddr(s)
+}
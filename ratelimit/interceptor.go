@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/imimran/go-grpc-auth/apperr"
+	"google.golang.org/grpc"
+)
+
+var ErrTooManyRequests = apperr.ResourceExhausted("TOO_MANY_REQUESTS", "too many requests, try again later")
+
+// Interceptor limits calls to the listed methods per client IP. Other
+// methods, and calls whose client address is unknown, are not limited.
+//
+// It runs before authentication so anonymous floods are cut off early.
+type Interceptor struct {
+	limiter *SlidingWindow
+	methods map[string]bool
+	proxies ProxyList
+}
+
+func NewInterceptor(limiter *SlidingWindow, methods []string, proxies ProxyList) *Interceptor {
+	set := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		set[m] = true
+	}
+	return &Interceptor{limiter: limiter, methods: set, proxies: proxies}
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := i.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (i *Interceptor) allow(ctx context.Context, method string) error {
+	if !i.methods[method] {
+		return nil
+	}
+	ip, ok := i.proxies.ClientIP(ctx)
+	if !ok {
+		return nil
+	}
+	// The method is part of the key so each one has its own budget
+	if ok, retryAfter := i.limiter.Allow(method+" "+ip, time.Now()); !ok {
+		return ErrTooManyRequests.WithRetryAfter(retryAfter)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Package ratelimit throttles RPCs per client IP with a sliding window.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SlidingWindow allows at most limit events per key within any window-long
+// period. It keeps the times of the last limit events per key, so the
+// answer is exact and the retry delay is known.
+type SlidingWindow struct {
+	limit  int
+	window time.Duration
+
+	mu     sync.Mutex
+	events map[string][]time.Time // oldest first, at most limit entries
+}
+
+func NewSlidingWindow(limit int, window time.Duration) *SlidingWindow {
+	return &SlidingWindow{limit: limit, window: window, events: make(map[string][]time.Time)}
+}
+
+// Allow records an event for key at now unless the limit is reached, in
+// which case it returns how long until the oldest event leaves the window.
+// Refused events are not recorded, so retrying early does not extend the wait.
+func (w *SlidingWindow) Allow(key string, now time.Time) (bool, time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	events := w.live(w.events[key], now)
+	if len(events) >= w.limit {
+		w.events[key] = events
+		return false, events[0].Add(w.window).Sub(now)
+	}
+	w.events[key] = append(events, now)
+	return true, 0
+}
+
+// live drops the events that left the window ending at now
+func (w *SlidingWindow) live(events []time.Time, now time.Time) []time.Time {
+	start := now.Add(-w.window)
+	i := 0
+	for i < len(events) && !events[i].After(start) {
+		i++
+	}
+	return events[i:]
+}
+
+// StartCleanup periodically forgets keys without events in the window until
+// ctx is cancelled.
+func (w *SlidingWindow) StartCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.purge(now)
+		}
+	}
+}
+
+func (w *SlidingWindow) purge(now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for key, events := range w.events {
+		if live := w.live(events, now); len(live) == 0 {
+			delete(w.events, key)
+		} else {
+			w.events[key] = live
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Package breached checks passwords against a local copy of the Have I Been
+// Pwned password list in its k-anonymity range format, without any network
+// calls.
+//
+// The list is a directory with one file per 5 hex digit SHA-1 prefix, named
+// after the prefix ("21BD1" or "21BD1.txt"), as written by the official
+// downloader. Each line is the remaining 35 hex digits and a breach count:
+//
+//	0018A45C4D1DEF81644B54AB7F969B88D65:10
+package breached
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const prefixLen = 5
+
+// RangeDir looks passwords up in a directory of range files. Only the file
+// for the password's prefix is read, so lookups stay cheap on the full list.
+type RangeDir struct {
+	dir      string
+	minCount int
+}
+
+// New opens the list in dir. Passwords seen fewer than minCount times are
+// accepted; HIBP pads responses with zero-count entries, so minCount is at
+// least 1.
+func New(dir string, minCount int) (*RangeDir, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("breached password list: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("breached password list: %s is not a directory", dir)
+	}
+	if minCount < 1 {
+		minCount = 1
+	}
+	return &RangeDir{dir: dir, minCount: minCount}, nil
+}
+
+// Contains reports whether password is on the list. A missing range file
+// means the prefix has no breached passwords; read errors are returned so a
+// broken list rejects passwords rather than silently allowing them.
+func (d *RangeDir) Contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:prefixLen], hash[prefixLen:]
+
+	f, err := d.open(prefix)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		lineSuffix, count, ok := strings.Cut(line, ":")
+		if !ok || !strings.EqualFold(lineSuffix, suffix) {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return false, fmt.Errorf("breached password list: range %s: bad count %q", prefix, count)
+		}
+		return n >= d.minCount, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("breached password list: range %s: %w", prefix, err)
+	}
+	return false, nil
+}
+
+func (d *RangeDir) open(prefix string) (*os.File, error) {
+	var err error
+	for _, name := range []string{prefix, prefix + ".txt", strings.ToLower(prefix), strings.ToLower(prefix) + ".txt"} {
+		var f *os.File
+		if f, err = os.Open(filepath.Join(d.dir, name)); err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
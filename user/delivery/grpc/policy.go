@@ -0,0 +1,19 @@
+package grpc
+
+import (
+	"github.com/imimran/go-grpc-auth/auth"
+	pb "github.com/imimran/go-grpc-auth/proto"
+	"github.com/imimran/go-grpc-auth/user/domain"
+)
+
+// Policy declares the permission each UserService RPC requires.
+// RPCs not listed here are open to any authenticated user.
+var Policy = auth.Policy{
+	pb.UserService_ListUsers_FullMethodName:  {Permission: domain.PermUsersList},
+	pb.UserService_GetUser_FullMethodName:    {Permission: domain.PermUsersRead, AllowSelf: true},
+	pb.UserService_UpdateUser_FullMethodName: {Permission: domain.PermUsersWrite, AllowSelf: true},
+	pb.UserService_DeleteUser_FullMethodName: {Permission: domain.PermUsersDelete, AllowSelf: true},
+	pb.UserService_GrantRole_FullMethodName:  {Permission: domain.PermRolesManage},
+	pb.UserService_RevokeRole_FullMethodName: {Permission: domain.PermRolesManage},
+	pb.UserService_UnlockUser_FullMethodName: {Permission: domain.PermUsersWrite},
+}
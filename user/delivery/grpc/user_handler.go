@@ -2,11 +2,21 @@ package grpc
 
 import (
 	"context"
+
+	"github.com/imimran/go-grpc-auth/apperr"
+	"github.com/imimran/go-grpc-auth/auth"
+	"github.com/imimran/go-grpc-auth/pagination"
+	"github.com/imimran/go-grpc-auth/user/domain"
 	"github.com/imimran/go-grpc-auth/user/usecase"
 	pb "github.com/imimran/go-grpc-auth/proto"
 	transformer "github.com/imimran/go-grpc-auth/user/transformer/grpc"
 )
 
+var (
+	errUnauthenticated    = apperr.Unauthenticated("UNAUTHENTICATED", "authentication required")
+	errStatusChangeDenied = apperr.PermissionDenied("STATUS_CHANGE_DENIED", "changing status requires "+domain.PermUsersWrite)
+)
+
 type UserHandler struct {
 	pb.UnimplementedUserServiceServer
 	userUsecase usecase.UserUsecase
@@ -33,7 +43,27 @@ func (h *UserHandler) GetUser(ctx context.Context, req *pb.UserId) (*pb.User, er
 }
 
 func (h *UserHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
-	user, err := h.userUsecase.Update(req.Id, req.Email, req.Password, req.FullName)
+	update, err := transformer.ToDomainUserUpdate(req)
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, errUnauthenticated
+	}
+	// The policy lets users update themselves; only admins change a status
+	if update.Status != nil && !claims.HasPermission(domain.PermUsersWrite) {
+		return nil, errStatusChangeDenied
+	}
+	// Users changing their own email or password must confirm the current
+	// one, so a stolen access token is not enough to take the account over
+	var currentPassword *string
+	if claims.UserID == req.Id && (update.Email != nil || update.Password != nil) {
+		current := req.GetCurrentPassword()
+		currentPassword = &current
+	}
+
+	user, err := h.userUsecase.Update(req.Id, update, currentPassword)
 	if err != nil {
 		return nil, err
 	}
@@ -45,25 +75,89 @@ func (h *UserHandler) DeleteUser(ctx context.Context, req *pb.UserId) (*pb.Empty
 	return &pb.Empty{}, err
 }
 
-func (h *UserHandler) ListUsers(ctx context.Context, req *pb.Empty) (*pb.UserListResponse, error) {
-    users, err := h.userUsecase.List()
+func (h *UserHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.UserListResponse, error) {
+    sort, err := domain.ParseUserSort(req.GetOrderBy())
     if err != nil {
         return nil, err
     }
 
-    resp := &pb.UserListResponse{
-        Users: transformer.ToProtoUserList(users),
+    page, err := h.userUsecase.List(transformer.ToDomainUserFilter(req), sort, pagination.Query{
+        Page:         int(req.GetPage()),
+        Limit:        int(req.GetLimit()),
+        Token:        req.GetPageToken(),
+        IncludeTotal: req.IncludeTotal,
+    })
+    if err != nil {
+        return nil, err
     }
 
-    return resp, nil
+    return transformer.ToProtoUserListResponse(page), nil
 }
 
 
 func (h *UserHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
-	token, err := h.userUsecase.Login(req.Email, req.Password)
+	tokens, err := h.userUsecase.Login(req.Email, req.Password)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.LoginResponse{Token: token}, nil
+	return transformer.ToProtoLoginResponse(tokens), nil
+}
+
+func (h *UserHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.LoginResponse, error) {
+	tokens, err := h.userUsecase.RefreshToken(req.GetRefreshToken())
+	if err != nil {
+		return nil, err
+	}
+
+	return transformer.ToProtoLoginResponse(tokens), nil
+}
+
+func (h *UserHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.Empty, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, errUnauthenticated
+	}
+
+	err := h.userUsecase.Logout(claims.UserID, claims.ID, claims.ExpiresAt.Time, req.GetRefreshToken())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Empty{}, nil
+}
+
+func (h *UserHandler) RevokeAllSessions(ctx context.Context, req *pb.Empty) (*pb.Empty, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errUnauthenticated
+	}
+
+	if err := h.userUsecase.RevokeAllSessions(userID); err != nil {
+		return nil, err
+	}
+	return &pb.Empty{}, nil
+}
+
+func (h *UserHandler) GrantRole(ctx context.Context, req *pb.RoleRequest) (*pb.User, error) {
+	user, err := h.userUsecase.GrantRole(req.GetUserId(), req.GetRole())
+	if err != nil {
+		return nil, err
+	}
+	return transformer.ToProtoUser(user), nil
+}
+
+func (h *UserHandler) RevokeRole(ctx context.Context, req *pb.RoleRequest) (*pb.User, error) {
+	user, err := h.userUsecase.RevokeRole(req.GetUserId(), req.GetRole())
+	if err != nil {
+		return nil, err
+	}
+	return transformer.ToProtoUser(user), nil
+}
+
+func (h *UserHandler) UnlockUser(ctx context.Context, req *pb.UserId) (*pb.User, error) {
+	user, err := h.userUsecase.Unlock(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return transformer.ToProtoUser(user), nil
 }
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/imimran/go-grpc-auth/apperr"
+)
+
+var (
+	ErrInvalidFilter = apperr.InvalidArgument("INVALID_FILTER", "invalid filter")
+	ErrInvalidSort   = apperr.InvalidArgument("INVALID_ORDER_BY", "invalid order_by")
+)
+
+// MaxFilterLength caps free-text filter values so a single request cannot
+// make the trigram search arbitrarily expensive.
+const MaxFilterLength = 100
+
+// UserFilter narrows ListUsers. Zero values mean "no filter"; all set fields
+// must match.
+type UserFilter struct {
+	EmailPrefix   string // case-insensitive
+	NameContains  string // case-insensitive substring of the full name
+	Role          string
+	Status        string
+	CreatedAfter  time.Time // inclusive
+	CreatedBefore time.Time // exclusive
+	// Query is free text; every word must appear in the email or full name
+	Query string
+}
+
+// Validate rejects unknown roles and statuses, inverted date ranges and
+// overlong text.
+func (f UserFilter) Validate() error {
+	if f.Role != "" && !IsValidRole(f.Role) {
+		return invalidFilter("role", "unknown role %q", f.Role)
+	}
+	if f.Status != "" && !IsValidStatus(f.Status) {
+		return invalidFilter("status", "unknown status %q", f.Status)
+	}
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && !f.CreatedAfter.Before(f.CreatedBefore) {
+		return invalidFilter("created_after", "created_after must be before created_before")
+	}
+	for _, t := range []struct{ name, value string }{
+		{"email_prefix", f.EmailPrefix},
+		{"name_contains", f.NameContains},
+		{"q", f.Query},
+	} {
+		if len(t.value) > MaxFilterLength {
+			return invalidFilter(t.name, "%s is longer than %d characters", t.name, MaxFilterLength)
+		}
+	}
+	return nil
+}
+
+func invalidFilter(field, format string, args ...any) error {
+	description := fmt.Sprintf(format, args...)
+	return ErrInvalidFilter.WithMessage("invalid filter: %s", description).WithField(field, description)
+}
+
+// Sortable ListUsers fields. The repository maps each to its own SQL
+// expression; nothing from the request reaches the query text.
+const (
+	SortCreatedAt = "created_at"
+	SortEmail     = "email"
+	SortFullName  = "full_name"
+)
+
+var sortFields = map[string]bool{SortCreatedAt: true, SortEmail: true, SortFullName: true}
+
+// UserSort orders ListUsers. Ties are broken by id in the same direction.
+type UserSort struct {
+	Field string
+	Desc  bool
+}
+
+// DefaultUserSort lists the newest users first.
+var DefaultUserSort = UserSort{Field: SortCreatedAt, Desc: true}
+
+// ParseUserSort reads an order_by value such as "email", "email asc" or
+// "created_at desc". Fields sort ascending by default except created_at,
+// and an empty value is DefaultUserSort.
+func ParseUserSort(orderBy string) (UserSort, error) {
+	parts := strings.Fields(strings.ToLower(orderBy))
+	if len(parts) == 0 {
+		return DefaultUserSort, nil
+	}
+	if len(parts) > 2 || !sortFields[parts[0]] {
+		return UserSort{}, invalidSort("expected one of created_at, email, full_name optionally followed by asc or desc")
+	}
+
+	sort := UserSort{Field: parts[0], Desc: parts[0] == SortCreatedAt}
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+			sort.Desc = false
+		case "desc":
+			sort.Desc = true
+		default:
+			return UserSort{}, invalidSort("direction must be asc or desc")
+		}
+	}
+	return sort, nil
+}
+
+func invalidSort(description string) error {
+	return ErrInvalidSort.WithMessage("invalid order_by: %s", description).WithField("order_by", description)
+}
+
+// String is the canonical form, e.g. "email asc".
+func (s UserSort) String() string {
+	if s.Desc {
+		return s.Field + " desc"
+	}
+	return s.Field + " asc"
+}
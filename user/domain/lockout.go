@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/imimran/go-grpc-auth/apperr"
+)
+
+// ErrAccountLocked refuses password confirmations of a signed-in user while
+// the account is locked. Login answers ErrInvalidCredentials instead so a
+// lock does not reveal that the email is registered.
+var ErrAccountLocked = apperr.ResourceExhausted("ACCOUNT_LOCKED", "too many failed logins, try again later")
+
+// maxBackoff caps the backoff when LockoutPolicy.MaxDelay is not set
+const maxBackoff = 24 * time.Hour
+
+// LockoutPolicy slows password guessing against one account. After
+// FreeAttempts consecutive failed logins the account is locked for
+// BaseDelay, doubling with every further failure up to MaxDelay; from
+// Threshold failures on it is locked for Duration each time. A successful
+// login or an admin unlock resets the count. The zero value never locks.
+type LockoutPolicy struct {
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	Threshold    int // 0 disables the temporary lockout
+	Duration     time.Duration
+}
+
+// LockFor returns how long to lock an account after its failures-th
+// consecutive failed login; 0 means not at all.
+func (p LockoutPolicy) LockFor(failures int) time.Duration {
+	if p.Threshold > 0 && failures >= p.Threshold {
+		return p.Duration
+	}
+	if p.BaseDelay <= 0 || failures <= p.FreeAttempts {
+		return 0
+	}
+
+	limit := p.MaxDelay
+	if limit <= 0 {
+		limit = maxBackoff
+	}
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failures && delay < limit; i++ {
+		delay *= 2
+	}
+	return min(delay, limit)
+}
+
+// LockedFor returns how much longer logins to the account are refused.
+func (u *User) LockedFor(now time.Time) time.Duration {
+	if u.LockedUntil == nil || !now.Before(*u.LockedUntil) {
+		return 0
+	}
+	return u.LockedUntil.Sub(now)
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/imimran/go-grpc-auth/apperr"
+)
+
+// MaxPasswordBytes is bcrypt's input limit; it would silently ignore the rest.
+const MaxPasswordBytes = 72
+
+var (
+	ErrWeakPassword     = apperr.InvalidArgument("WEAK_PASSWORD", "password does not meet the password policy")
+	ErrBreachedPassword = apperr.InvalidArgument("BREACHED_PASSWORD", "password appears in a known data breach, choose another").
+				WithField("password", "appears in a known data breach")
+)
+
+// BreachedPasswords reports whether a password is known to be compromised
+// (implemented by user/breached).
+type BreachedPasswords interface {
+	Contains(password string) (bool, error)
+}
+
+// PasswordHasher encodes passwords for storage (implemented by user/hasher).
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify reports whether password matches encoded, whichever supported
+	// algorithm and cost encoded was made with
+	Verify(encoded, password string) (bool, error)
+	// NeedsRehash reports whether Hash would encode differently, i.e. encoded
+	// uses an outdated algorithm or cost
+	NeedsRehash(encoded string) bool
+}
+
+// PasswordPolicy decides which passwords are accepted and how they are
+// stored. It is checked whenever a password is set; existing passwords are
+// never re-checked.
+type PasswordPolicy struct {
+	Hasher PasswordHasher // required to set passwords
+
+	MinLength int // in characters
+	MaxLength int // in bytes; 0 or anything above MaxPasswordBytes means MaxPasswordBytes
+	// MinCharClasses is how many of lower case letters, upper case letters,
+	// digits and symbols the password must mix
+	MinCharClasses int
+	Breached       BreachedPasswords // optional
+}
+
+// Check validates password for the account with the given email.
+func (p PasswordPolicy) Check(password, email string) error {
+	if password == "" {
+		return ErrPasswordRequired
+	}
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return weakPassword("must be at least %d characters", p.MinLength)
+	}
+	maxLength := p.MaxLength
+	if maxLength <= 0 || maxLength > MaxPasswordBytes {
+		maxLength = MaxPasswordBytes
+	}
+	if len(password) > maxLength {
+		return weakPassword("must be at most %d bytes", maxLength)
+	}
+	if charClasses(password) < p.MinCharClasses {
+		return weakPassword("must mix at least %d of lower case letters, upper case letters, digits and symbols", p.MinCharClasses)
+	}
+	if isEmailLike(password, email) {
+		return weakPassword("must not be the email address")
+	}
+
+	if p.Breached != nil {
+		found, err := p.Breached.Contains(password)
+		if err != nil {
+			return err
+		}
+		if found {
+			return ErrBreachedPassword
+		}
+	}
+	return nil
+}
+
+func weakPassword(format string, args ...any) error {
+	description := fmt.Sprintf(format, args...)
+	return ErrWeakPassword.WithMessage("password %s", description).WithField("password", description)
+}
+
+func charClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsLetter(r):
+			// Letters without case count as lower case
+			lower = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+// isEmailLike reports whether password is the email address or its local part
+func isEmailLike(password, email string) bool {
+	if email == "" {
+		return false
+	}
+	password = strings.TrimSpace(password)
+	local, _, _ := strings.Cut(email, "@")
+	return strings.EqualFold(password, email) || strings.EqualFold(password, local)
+}
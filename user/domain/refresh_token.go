@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken is an opaque, single-use credential exchanged for a new access token.
+// Only the SHA-256 hash is persisted. Tokens rotated from the same login share a
+// FamilyID so the whole chain can be revoked when a used token is replayed.
+type RefreshToken struct {
+	ID        int64     `gorm:"primaryKey"`
+	UserID    int64     `gorm:"index;not null"`
+	FamilyID  uuid.UUID `gorm:"type:uuid;index;not null"`
+	TokenHash string    `gorm:"uniqueIndex;not null;type:char(64)"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// NewRefreshToken generates a random token for the given family and returns
+// the model to persist together with the plain value to hand to the client.
+func NewRefreshToken(userID int64, familyID uuid.UUID, ttl time.Duration) (*RefreshToken, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	plain := base64.RawURLEncoding.EncodeToString(b)
+
+	return &RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: HashRefreshToken(plain),
+		ExpiresAt: time.Now().Add(ttl),
+	}, plain, nil
+}
+
+// HashRefreshToken returns the hex encoded SHA-256 of a plain refresh token.
+func HashRefreshToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// RevokedToken blacklists a single access token by its jti claim until it
+// would have expired anyway.
+type RevokedToken struct {
+	JTI       string    `gorm:"primaryKey;type:varchar(64)"`
+	UserID    int64     `gorm:"index;not null"`
+	ExpiresAt time.Time `gorm:"index;not null"`
+	CreatedAt time.Time
+}
+
+// SessionRevocation invalidates every access token of a user issued at or
+// before RevokedAt. ExpiresAt is when the last affected token expires, after
+// which the row can be cleaned up.
+type SessionRevocation struct {
+	UserID    int64     `gorm:"primaryKey;autoIncrement:false"`
+	RevokedAt time.Time `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"index;not null"`
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/imimran/go-grpc-auth/apperr"
+)
+
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Permissions are embedded in access tokens and checked by the auth policy.
+const (
+	PermUsersRead   = "users:read"
+	PermUsersList   = "users:list"
+	PermUsersWrite  = "users:write"
+	PermUsersDelete = "users:delete"
+	PermRolesManage = "roles:manage"
+
+	// PermAddressesManage lets the holder see and modify every user's addresses
+	PermAddressesManage = "addresses:manage"
+)
+
+var ErrInvalidRole = apperr.InvalidArgument("INVALID_ROLE", "unknown role").WithField("role", "unknown role")
+
+// rolePermissions is the source of truth for what each role may do.
+// Plain users get no extra permissions: they can only act on themselves.
+var rolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermUsersRead,
+		PermUsersList,
+		PermUsersWrite,
+		PermUsersDelete,
+		PermRolesManage,
+		PermAddressesManage,
+	},
+	RoleUser: {},
+}
+
+// UserRole assigns a role to a user.
+type UserRole struct {
+	UserID    int64  `gorm:"primaryKey;autoIncrement:false"`
+	Role      string `gorm:"primaryKey;type:varchar(32)"`
+	CreatedAt time.Time
+}
+
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// PermissionsFor returns the sorted, de-duplicated permissions granted by roles.
+func PermissionsFor(roles []string) []string {
+	seen := map[string]bool{}
+	perms := []string{}
+	for _, role := range roles {
+		for _, p := range rolePermissions[role] {
+			if !seen[p] {
+				seen[p] = true
+				perms = append(perms, p)
+			}
+		}
+	}
+	sort.Strings(perms)
+	return perms
+}
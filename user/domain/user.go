@@ -1,25 +1,59 @@
 package domain
 
 import (
-	"errors"
-	"golang.org/x/crypto/bcrypt"
+	"time"
+
+	"github.com/imimran/go-grpc-auth/apperr"
+)
+
+// Account statuses. Disabled users cannot log in or refresh their tokens.
+const (
+	StatusActive   = "active"
+	StatusDisabled = "disabled"
 )
 
+var (
+	ErrUserNotFound       = apperr.NotFound("USER_NOT_FOUND", "user not found")
+	ErrEmailTaken         = apperr.AlreadyExists("EMAIL_TAKEN", "email already exists")
+	ErrInvalidCredentials = apperr.Unauthenticated("INVALID_CREDENTIALS", "invalid email or password")
+	ErrAccountDisabled    = apperr.PermissionDenied("ACCOUNT_DISABLED", "account is disabled")
+	ErrEmailRequired      = apperr.InvalidArgument("EMAIL_REQUIRED", "email is required").WithField("email", "is required")
+	ErrPasswordRequired   = apperr.InvalidArgument("PASSWORD_REQUIRED", "password is required").WithField("password", "is required")
+	ErrInvalidStatus      = apperr.InvalidArgument("INVALID_STATUS", "unknown status").WithField("status", "must be active or disabled")
+
+	// Users changing their own email or password must confirm the current one
+	ErrCurrentPasswordRequired = apperr.InvalidArgument("CURRENT_PASSWORD_REQUIRED", "current_password is required to change your email or password").
+					WithField("current_password", "is required")
+	ErrWrongCurrentPassword = apperr.InvalidArgument("WRONG_CURRENT_PASSWORD", "current password is incorrect").
+				WithField("current_password", "is incorrect")
+)
+
+func IsValidStatus(status string) bool {
+	return status == StatusActive || status == StatusDisabled
+}
+
 type User struct {
 	ID int64 `gorm:"primaryKey"`
 	Email    string `gorm:"unique;not null;type:varchar(255)"`
-	Password string `gorm:"not null"` // Stored as bcrypt hash
+	Password string `gorm:"not null"` // Stored as argon2id or bcrypt hash
 	FullName string `gorm:"type:varchar(255)"`
+	Roles    []UserRole `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Status   string `gorm:"type:varchar(16);not null;default:active"`
+	CreatedAt time.Time
+
+	// Consecutive failed logins and the lock they caused, see LockoutPolicy
+	FailedLogins int `gorm:"not null;default:0"`
+	LockedUntil  *time.Time
 }
 
-func NewUser(email, password, fullName string) (*User, error) {
+func NewUser(email, password, fullName string, policy PasswordPolicy) (*User, error) {
 	if email == "" {
-		return nil, errors.New("email is required")
+		return nil, ErrEmailRequired
 	}
-	if password == "" {
-		return nil, errors.New("password is required")
+	if err := policy.Check(password, email); err != nil {
+		return nil, err
 	}
-	hashed, err := hashPassword(password)
+	hashed, err := policy.Hasher.Hash(password)
 	if err != nil {
 		return nil, err
 	}
@@ -28,32 +62,85 @@ func NewUser(email, password, fullName string) (*User, error) {
 		Email:    email,
 		Password: hashed,
 		FullName: fullName,
+		Status:   StatusActive,
+		Roles:    []UserRole{{Role: RoleUser}},
 	}, nil
 }
 
-func (u *User) Update(email, password, fullName string) error {
-	if email != "" {
-		u.Email = email
+// UserUpdate lists the fields to change; nil fields are left alone.
+type UserUpdate struct {
+	Email    *string
+	Password *string
+	FullName *string
+	Status   *string
+}
+
+// Update applies the set fields. Email and password cannot be cleared;
+// the full name can. A new password must satisfy policy, checked against
+// the updated email.
+func (u *User) Update(update UserUpdate, policy PasswordPolicy) error {
+	if update.Email != nil {
+		if *update.Email == "" {
+			return ErrEmailRequired
+		}
+		u.Email = *update.Email
 	}
-	if password != "" {
-		hashed, err := hashPassword(password)
+	if update.Password != nil {
+		if err := policy.Check(*update.Password, u.Email); err != nil {
+			return err
+		}
+		hashed, err := policy.Hasher.Hash(*update.Password)
 		if err != nil {
 			return err
 		}
 		u.Password = hashed
 	}
-	if fullName != "" {
-		u.FullName = fullName
+	if update.FullName != nil {
+		u.FullName = *update.FullName
+	}
+	if update.Status != nil {
+		if !IsValidStatus(*update.Status) {
+			return ErrInvalidStatus.WithMessage("unknown status %q", *update.Status)
+		}
+		u.Status = *update.Status
 	}
 	return nil
 }
 
-func (u *User) CheckPassword(password string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
-	return err == nil
+// RoleNames returns the names of the roles assigned to the user.
+func (u *User) RoleNames() []string {
+	names := make([]string, 0, len(u.Roles))
+	for _, r := range u.Roles {
+		names = append(names, r.Role)
+	}
+	return names
 }
 
-func hashPassword(password string) (string, error) {
-	b, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	return string(b), err
+func (u *User) HasRole(role string) bool {
+	for _, r := range u.Roles {
+		if r.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPassword reports whether password matches the stored hash. Errors
+// mean the stored hash itself is unreadable.
+func (u *User) CheckPassword(password string, hasher PasswordHasher) (bool, error) {
+	return hasher.Verify(u.Password, password)
+}
+
+// RehashPassword re-encodes an already checked password when the stored
+// hash uses an outdated algorithm or cost. It reports whether it did.
+func (u *User) RehashPassword(password string, hasher PasswordHasher) (bool, error) {
+	if !hasher.NeedsRehash(u.Password) {
+		return false, nil
+	}
+	hashed, err := hasher.Hash(password)
+	if err != nil {
+		return false, err
+	}
+	u.Password = hashed
+	return true, nil
 }
@@ -0,0 +1,66 @@
+// Package hasher implements domain.PasswordHasher with argon2id and bcrypt.
+package hasher
+
+import (
+	"fmt"
+
+	"github.com/imimran/go-grpc-auth/config"
+	"github.com/imimran/go-grpc-auth/user/domain"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// scheme is one algorithm at fixed cost
+type scheme interface {
+	domain.PasswordHasher
+	// matches reports whether encoded was made by this algorithm, at any cost
+	matches(encoded string) bool
+}
+
+// Hasher hashes new passwords with the configured algorithm and verifies
+// hashes made by either. Hashes from the other algorithm, or from the same
+// one at another cost, need a rehash.
+type Hasher struct {
+	preferred scheme
+	schemes   []scheme
+}
+
+// New builds the hasher for cfg.Algorithm, "argon2id" or "bcrypt".
+func New(cfg config.PasswordHashConfig) (*Hasher, error) {
+	argon := Argon2id{Memory: cfg.Argon2Memory, Iterations: cfg.Argon2Iterations, Parallelism: cfg.Argon2Parallelism}
+	bcr := Bcrypt{Cost: cfg.BcryptCost}
+
+	var preferred scheme
+	switch cfg.Algorithm {
+	case "argon2id":
+		if argon.Iterations < 1 || argon.Parallelism < 1 || argon.Memory < 8*uint32(argon.Parallelism) {
+			return nil, fmt.Errorf("argon2id needs iterations and parallelism of at least 1 and 8 KiB of memory per lane")
+		}
+		preferred = argon
+	case "bcrypt":
+		if bcr.Cost < bcrypt.MinCost || bcr.Cost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		preferred = bcr
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm %q", cfg.Algorithm)
+	}
+
+	return &Hasher{preferred: preferred, schemes: []scheme{argon, bcr}}, nil
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	return h.preferred.Hash(password)
+}
+
+func (h *Hasher) Verify(encoded, password string) (bool, error) {
+	for _, s := range h.schemes {
+		if s.matches(encoded) {
+			return s.Verify(encoded, password)
+		}
+	}
+	return false, fmt.Errorf("unrecognized password hash format")
+}
+
+func (h *Hasher) NeedsRehash(encoded string) bool {
+	return !h.preferred.matches(encoded) || h.preferred.NeedsRehash(encoded)
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/imimran/go-grpc-auth/user/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RevocationRepository interface {
+	RevokeToken(token *domain.RevokedToken) error
+	IsTokenRevoked(jti string) (bool, error)
+	RevokeSessions(revocation *domain.SessionRevocation) error
+	// SessionsRevokedAt returns nil when the user never revoked all sessions.
+	SessionsRevokedAt(userID int64) (*time.Time, error)
+	DeleteExpired(now time.Time) error
+}
+
+type revocationRepository struct {
+	db *gorm.DB
+}
+
+func NewRevocationRepository(db *gorm.DB) RevocationRepository {
+	return &revocationRepository{db: db}
+}
+
+func (r *revocationRepository) RevokeToken(token *domain.RevokedToken) error {
+	// Logging out twice with the same token is not an error
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+func (r *revocationRepository) IsTokenRevoked(jti string) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *revocationRepository) RevokeSessions(revocation *domain.SessionRevocation) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"revoked_at", "expires_at"}),
+	}).Create(revocation).Error
+}
+
+func (r *revocationRepository) SessionsRevokedAt(userID int64) (*time.Time, error) {
+	var revocation domain.SessionRevocation
+	err := r.db.Where("user_id = ?", userID).First(&revocation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &revocation.RevokedAt, nil
+}
+
+func (r *revocationRepository) DeleteExpired(now time.Time) error {
+	if err := r.db.Where("expires_at < ?", now).Delete(&domain.RevokedToken{}).Error; err != nil {
+		return err
+	}
+	return r.db.Where("expires_at < ?", now).Delete(&domain.SessionRevocation{}).Error
+}
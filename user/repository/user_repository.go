@@ -2,10 +2,16 @@ package repository
 
 import (
 	"errors"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/imimran/go-grpc-auth/pagination"
 	"github.com/imimran/go-grpc-auth/user/domain"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository interface {
@@ -14,7 +20,20 @@ type UserRepository interface {
 	GetByEmail(email string) (*domain.User, error)
 	Update(user *domain.User) error
 	Delete(id int64) error
-	List() ([]*domain.User, error)
+	// List returns up to limit users matching filter in sort order, starting
+	// after the cursor when it is set and skipping offset rows otherwise.
+	List(filter domain.UserFilter, sort domain.UserSort, after *pagination.Cursor, offset, limit int) ([]*domain.User, error)
+	Count(filter domain.UserFilter) (int64, error)
+	AddRole(userID int64, role string) error
+	RemoveRole(userID int64, role string) error
+	// RecordFailedLogin counts a failed login and returns the new number of
+	// consecutive failures. The increment is atomic across servers.
+	RecordFailedLogin(id int64) (int, error)
+	// LockUntil refuses logins until the given time; a later existing lock
+	// is kept.
+	LockUntil(id int64, until time.Time) error
+	// ResetFailedLogins clears the failure count and any lock.
+	ResetFailedLogins(id int64) error
 }
 
 type userRepository struct {
@@ -26,41 +45,181 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 }
 
 func (r *userRepository) Create(user *domain.User) error {
-	return r.db.Create(user).Error
+	err := r.db.Create(user).Error
+	if isUniqueViolation(err) {
+		return domain.ErrEmailTaken
+	}
+	return err
 }
 
 func (r *userRepository) GetByID(id int64) (*domain.User, error) {
 	var user domain.User
-	if err := r.db.First(&user, id).Error; err != nil {
-		return nil, err
+	if err := r.db.Preload("Roles").First(&user, id).Error; err != nil {
+		return nil, notFound(err)
 	}
 	return &user, nil
 }
 
 func (r *userRepository) GetByEmail(email string) (*domain.User, error) {
 	var user domain.User
-	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
-		return nil, err
+	if err := r.db.Preload("Roles").Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, notFound(err)
 	}
 	return &user, nil
 }
 
+// Update saves the user's own columns. Roles are managed through
+// AddRole/RemoveRole and the lockout state through the login methods, so a
+// concurrent failed login is never overwritten.
 func (r *userRepository) Update(user *domain.User) error {
-	return r.db.Save(user).Error
+	err := r.db.Omit("Roles", "FailedLogins", "LockedUntil").Save(user).Error
+	if isUniqueViolation(err) {
+		return domain.ErrEmailTaken
+	}
+	return err
 }
 
 func (r *userRepository) Delete(id int64) error {
 	res := r.db.Delete(&domain.User{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) RecordFailedLogin(id int64) (int, error) {
+	var failures int
+	res := r.db.Raw("UPDATE users SET failed_logins = failed_logins + 1 WHERE id = ? RETURNING failed_logins", id).Scan(&failures)
+	if res.Error != nil {
+		return 0, res.Error
+	}
 	if res.RowsAffected == 0 {
-		return errors.New("user not found")
+		return 0, domain.ErrUserNotFound
 	}
-	return res.Error
+	return failures, nil
+}
+
+func (r *userRepository) LockUntil(id int64, until time.Time) error {
+	return r.db.Model(&domain.User{}).Where("id = ?", id).
+		Update("locked_until", gorm.Expr("GREATEST(COALESCE(locked_until, ?), ?)", until, until)).Error
 }
 
-func (r *userRepository) List() ([]*domain.User, error) {
+func (r *userRepository) ResetFailedLogins(id int64) error {
+	res := r.db.Model(&domain.User{}).Where("id = ?", id).
+		Updates(map[string]any{"failed_logins": 0, "locked_until": nil})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// notFound reports a missing row as domain.ErrUserNotFound
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrUserNotFound
+	}
+	return err
+}
+
+// isUniqueViolation reports a Postgres unique_violation (SQLSTATE 23505)
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func (r *userRepository) List(filter domain.UserFilter, sort domain.UserSort, after *pagination.Cursor, offset, limit int) ([]*domain.User, error) {
+	column, ok := userSortColumns[sort.Field]
+	if !ok {
+		return nil, domain.ErrInvalidSort
+	}
+	dir, cmp := "ASC", ">"
+	if sort.Desc {
+		dir, cmp = "DESC", "<"
+	}
+
 	var users []*domain.User
-	if err := r.db.Find(&users).Error; err != nil {
+	q := applyUserFilter(r.db.Preload("Roles"), filter)
+	if after != nil {
+		id, err := strconv.ParseInt(after.ID, 10, 64)
+		if err != nil {
+			return nil, pagination.ErrInvalidPageToken
+		}
+		var key any = after.Key
+		if sort.Field == domain.SortCreatedAt {
+			key = after.CreatedAt
+		}
+		// Row comparison keeps ties on the sort column in id order
+		q = q.Where("("+column+", users.id) "+cmp+" (?, ?)", key, id)
+	} else if offset > 0 {
+		q = q.Offset(offset)
+	}
+	q = q.Order(column + " " + dir).Order("users.id " + dir)
+	if err := q.Limit(limit).Find(&users).Error; err != nil {
 		return nil, err
 	}
 	return users, nil
 }
+
+func (r *userRepository) Count(filter domain.UserFilter) (int64, error) {
+	var total int64
+	err := applyUserFilter(r.db.Model(&domain.User{}), filter).Count(&total).Error
+	return total, err
+}
+
+// userSortColumns whitelists the SQL behind each sort field
+var userSortColumns = map[string]string{
+	domain.SortCreatedAt: "users.created_at",
+	domain.SortEmail:     "users.email",
+	domain.SortFullName:  "COALESCE(users.full_name, '')",
+}
+
+// userSearchColumn is the free-text search expression; it must match
+// idx_users_search_trgm for the index to be used.
+const userSearchColumn = "(users.email || ' ' || COALESCE(users.full_name, ''))"
+
+// applyUserFilter adds a WHERE clause for every non-zero filter field. Values
+// are always bound parameters.
+func applyUserFilter(q *gorm.DB, filter domain.UserFilter) *gorm.DB {
+	if filter.EmailPrefix != "" {
+		q = q.Where("lower(users.email) LIKE ?", escapeLike(strings.ToLower(filter.EmailPrefix))+"%")
+	}
+	if filter.NameContains != "" {
+		q = q.Where("users.full_name ILIKE ?", "%"+escapeLike(filter.NameContains)+"%")
+	}
+	if filter.Role != "" {
+		q = q.Where("EXISTS (SELECT 1 FROM user_roles WHERE user_roles.user_id = users.id AND user_roles.role = ?)", filter.Role)
+	}
+	if filter.Status != "" {
+		q = q.Where("users.status = ?", filter.Status)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		q = q.Where("users.created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		q = q.Where("users.created_at < ?", filter.CreatedBefore)
+	}
+	for _, word := range strings.Fields(filter.Query) {
+		q = q.Where(userSearchColumn+" ILIKE ?", "%"+escapeLike(word)+"%")
+	}
+	return q
+}
+
+// escapeLike makes LIKE wildcards in user input match literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func (r *userRepository) AddRole(userID int64, role string) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.UserRole{UserID: userID, Role: role}).Error
+}
+
+func (r *userRepository) RemoveRole(userID int64, role string) error {
+	return r.db.Where("user_id = ? AND role = ?", userID, role).Delete(&domain.UserRole{}).Error
+}
@@ -1,18 +1,52 @@
 package transformer
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/imimran/go-grpc-auth/apperr"
+	"github.com/imimran/go-grpc-auth/pagination"
 	pb "github.com/imimran/go-grpc-auth/proto"
 	"github.com/imimran/go-grpc-auth/user/domain"
+	"github.com/imimran/go-grpc-auth/user/usecase"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // ToProtoUser converts a domain.User model to a gRPC pb.User message.
 // It explicitly omits the hashed password for security.
 func ToProtoUser(user *domain.User) *pb.User {
-	return &pb.User{
+	protoUser := &pb.User{
 		Id:    user.ID,
 		Email: user.Email,
 		FullName: user.FullName,
+		Roles:    user.RoleNames(),
+		Status:   user.Status,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+	}
+	// Expired locks are left in the database until the next login
+	if user.LockedFor(time.Now()) > 0 {
+		protoUser.LockedUntil = timestamppb.New(*user.LockedUntil)
+	}
+	return protoUser
+}
+
+// ToDomainUserFilter reads the ListUsers filters; unset timestamps stay zero.
+func ToDomainUserFilter(req *pb.ListUsersRequest) domain.UserFilter {
+	filter := domain.UserFilter{
+		EmailPrefix:  strings.TrimSpace(req.GetEmailPrefix()),
+		NameContains: strings.TrimSpace(req.GetNameContains()),
+		Role:         req.GetRole(),
+		Status:       req.GetStatus(),
+		Query:        strings.TrimSpace(req.GetQ()),
 	}
+	if req.CreatedAfter != nil {
+		filter.CreatedAfter = req.CreatedAfter.AsTime()
+	}
+	if req.CreatedBefore != nil {
+		filter.CreatedBefore = req.CreatedBefore.AsTime()
+	}
+	return filter
 }
 
 // ToProtoUserList converts a slice of domain.User to a slice of pb.User.
@@ -23,3 +57,65 @@ func ToProtoUserList(users []*domain.User) []*pb.User {
 	}
 	return protoUsers
 }
+
+// ToProtoUserListResponse converts one page of users, with its total and
+// next page token.
+func ToProtoUserListResponse(page *pagination.Page[*domain.User]) *pb.UserListResponse {
+	return &pb.UserListResponse{
+		Users:         ToProtoUserList(page.Items),
+		Total:         page.Total,
+		Page:          int32(page.Page),
+		NextPageToken: page.NextPageToken,
+	}
+}
+
+// ToDomainUserUpdate maps an UpdateUserRequest to the fields to change. With
+// an update_mask exactly the listed fields are set, empty values included;
+// without one every non-empty field is, as before masks existed.
+func ToDomainUserUpdate(req *pb.UpdateUserRequest) (domain.UserUpdate, error) {
+	email, password, fullName, status := req.GetEmail(), req.GetPassword(), req.GetFullName(), req.GetStatus()
+
+	var update domain.UserUpdate
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		if email != "" {
+			update.Email = &email
+		}
+		if password != "" {
+			update.Password = &password
+		}
+		if fullName != "" {
+			update.FullName = &fullName
+		}
+		if status != "" {
+			update.Status = &status
+		}
+		return update, nil
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "email":
+			update.Email = &email
+		case "password":
+			update.Password = &password
+		case "full_name":
+			update.FullName = &fullName
+		case "status":
+			update.Status = &status
+		default:
+			return domain.UserUpdate{}, apperr.InvalidField("update_mask", fmt.Sprintf("unknown update_mask path %q, expected email, password, full_name or status", path))
+		}
+	}
+	return update, nil
+}
+
+// ToProtoLoginResponse converts an issued token pair to the Login/RefreshToken response.
+func ToProtoLoginResponse(tokens *usecase.TokenPair) *pb.LoginResponse {
+	return &pb.LoginResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    int64(tokens.ExpiresIn.Seconds()),
+		TokenType:    "Bearer",
+	}
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/imimran/go-grpc-auth/user/domain"
+	"github.com/imimran/go-grpc-auth/user/repository"
+)
+
+// RevocationStore answers "has this access token been revoked?" for the auth
+// interceptor. Postgres is the source of truth so revocations are shared by
+// every replica; lookups are cached in-process so the hot path rarely hits
+// the database.
+//
+// Positive answers never change and are cached until the token expires.
+// Negative answers are cached for cacheTTL only, which bounds how long a
+// revocation made on another replica can go unnoticed.
+type RevocationStore struct {
+	repo     repository.RevocationRepository
+	tokenTTL time.Duration
+	cacheTTL time.Duration
+
+	mu       sync.RWMutex
+	tokens   map[string]cacheEntry // jti -> revoked?
+	sessions map[int64]sessionEntry
+}
+
+type cacheEntry struct {
+	revoked bool
+	until   time.Time
+}
+
+type sessionEntry struct {
+	revokedAt *time.Time
+	until     time.Time
+}
+
+// NewRevocationStore builds a store. tokenTTL is the access token lifetime,
+// used to know how long a user-wide revocation must be kept.
+func NewRevocationStore(repo repository.RevocationRepository, tokenTTL, cacheTTL time.Duration) *RevocationStore {
+	return &RevocationStore{
+		repo:     repo,
+		tokenTTL: tokenTTL,
+		cacheTTL: cacheTTL,
+		tokens:   make(map[string]cacheEntry),
+		sessions: make(map[int64]sessionEntry),
+	}
+}
+
+// RevokeToken blacklists a single access token until its expiry.
+func (s *RevocationStore) RevokeToken(jti string, userID int64, expiresAt time.Time) error {
+	err := s.repo.RevokeToken(&domain.RevokedToken{JTI: jti, UserID: userID, ExpiresAt: expiresAt})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.tokens[jti] = cacheEntry{revoked: true, until: expiresAt}
+	s.mu.Unlock()
+	return nil
+}
+
+// RevokeUser invalidates every access token issued to the user so far.
+func (s *RevocationStore) RevokeUser(userID int64) error {
+	now := time.Now()
+	err := s.repo.RevokeSessions(&domain.SessionRevocation{
+		UserID:    userID,
+		RevokedAt: now,
+		ExpiresAt: now.Add(s.tokenTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.sessions[userID] = sessionEntry{revokedAt: &now, until: now.Add(s.cacheTTL)}
+	s.mu.Unlock()
+	return nil
+}
+
+// IsRevoked reports whether the token identified by jti, issued to userID at
+// issuedAt, has been revoked individually or by a user-wide revocation.
+func (s *RevocationStore) IsRevoked(jti string, userID int64, issuedAt time.Time) (bool, error) {
+	revoked, err := s.isTokenRevoked(jti)
+	if err != nil || revoked {
+		return revoked, err
+	}
+
+	revokedAt, err := s.sessionsRevokedAt(userID)
+	if err != nil || revokedAt == nil {
+		return false, err
+	}
+	// Tokens carry their issue time in milliseconds; only one issued within
+	// the same millisecond as the revocation is treated as revoked. Tokens
+	// with a whole-second iat alone are revoked throughout that second.
+	return issuedAt.UnixMilli() <= revokedAt.UnixMilli(), nil
+}
+
+func (s *RevocationStore) isTokenRevoked(jti string) (bool, error) {
+	now := time.Now()
+
+	s.mu.RLock()
+	entry, ok := s.tokens[jti]
+	s.mu.RUnlock()
+	if ok && now.Before(entry.until) {
+		return entry.revoked, nil
+	}
+
+	revoked, err := s.repo.IsTokenRevoked(jti)
+	if err != nil {
+		return false, err
+	}
+
+	until := now.Add(s.cacheTTL)
+	if revoked {
+		until = now.Add(s.tokenTTL)
+	}
+	s.mu.Lock()
+	s.tokens[jti] = cacheEntry{revoked: revoked, until: until}
+	s.mu.Unlock()
+	return revoked, nil
+}
+
+func (s *RevocationStore) sessionsRevokedAt(userID int64) (*time.Time, error) {
+	now := time.Now()
+
+	s.mu.RLock()
+	entry, ok := s.sessions[userID]
+	s.mu.RUnlock()
+	if ok && now.Before(entry.until) {
+		return entry.revokedAt, nil
+	}
+
+	revokedAt, err := s.repo.SessionsRevokedAt(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.sessions[userID] = sessionEntry{revokedAt: revokedAt, until: now.Add(s.cacheTTL)}
+	s.mu.Unlock()
+	return revokedAt, nil
+}
+
+// StartCleanup periodically purges expired revocations from Postgres and the
+// in-process cache until ctx is cancelled.
+func (s *RevocationStore) StartCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.repo.DeleteExpired(now); err != nil {
+				log.Printf("Revocation cleanup failed: %v", err)
+			}
+			s.purgeCache(now)
+		}
+	}
+}
+
+func (s *RevocationStore) purgeCache(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for jti, entry := range s.tokens {
+		if !now.Before(entry.until) {
+			delete(s.tokens, jti)
+		}
+	}
+	for userID, entry := range s.sessions {
+		if !now.Before(entry.until) {
+			delete(s.sessions, userID)
+		}
+	}
+}
@@ -2,39 +2,86 @@ package usecase
 
 import (
 	"errors"
+	"log"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/imimran/go-grpc-auth/apperr"
+	"github.com/imimran/go-grpc-auth/pagination"
 	"github.com/imimran/go-grpc-auth/user/domain"
 	"github.com/imimran/go-grpc-auth/user/repository"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+var (
+	ErrInvalidRefreshToken = apperr.Unauthenticated("INVALID_REFRESH_TOKEN", "invalid or expired refresh token")
+	ErrRefreshTokenReused  = apperr.Unauthenticated("REFRESH_TOKEN_REUSED", "refresh token reuse detected, session revoked")
+)
+
+// TokenSigner signs access token claims (implemented by auth.KeySet).
+type TokenSigner interface {
+	Sign(claims jwt.Claims) (string, error)
+}
+
+// TokenOptions controls how access and refresh tokens are issued.
+type TokenOptions struct {
+	Signer          TokenSigner
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// TokenPair is returned by Login and RefreshToken.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+}
+
 type UserUsecase interface {
 	Register(email, password, fullName string) (*domain.User, error)
-	Login(email, password string) (string, error)
+	Login(email, password string) (*TokenPair, error)
+	RefreshToken(refreshToken string) (*TokenPair, error)
+	Logout(userID int64, jti string, expiresAt time.Time, refreshToken string) error
+	RevokeAllSessions(userID int64) error
 	Get(id int64) (*domain.User, error)
-	Update(id int64, email, password, fullName string) (*domain.User, error)
+	// Update applies update; a non-nil currentPassword must match first
+	Update(id int64, update domain.UserUpdate, currentPassword *string) (*domain.User, error)
 	Delete(id int64) error
-	List() ([]*domain.User, error)
+	List(filter domain.UserFilter, sort domain.UserSort, query pagination.Query) (*pagination.Page[*domain.User], error)
+	GrantRole(userID int64, role string) (*domain.User, error)
+	RevokeRole(userID int64, role string) (*domain.User, error)
+	Unlock(id int64) (*domain.User, error)
 }
 
 type userUsecase struct {
-	repo      repository.UserRepository
-	jwtSecret []byte
+	repo        repository.UserRepository
+	refreshRepo repository.RefreshTokenRepository
+	revocations *RevocationStore
+	tokens      TokenOptions
+	passwords   domain.PasswordPolicy
+	lockout     domain.LockoutPolicy
+
+	dummyHashOnce sync.Once
+	dummyHash     string
 }
 
-func NewUserUsecase(repo repository.UserRepository, jwtSecret []byte) UserUsecase {
-	return &userUsecase{repo: repo, jwtSecret: jwtSecret}
+func NewUserUsecase(repo repository.UserRepository, refreshRepo repository.RefreshTokenRepository, revocations *RevocationStore, tokens TokenOptions, passwords domain.PasswordPolicy, lockout domain.LockoutPolicy) UserUsecase {
+	return &userUsecase{repo: repo, refreshRepo: refreshRepo, revocations: revocations, tokens: tokens, passwords: passwords, lockout: lockout}
 }
 
 func (u *userUsecase) Register(email, password, fullName string) (*domain.User, error) {
 	_, err := u.repo.GetByEmail(email)
 	if err == nil {
-		return nil, errors.New("email already exists")
+		return nil, domain.ErrEmailTaken
+	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
 	}
 
-	user, err := domain.NewUser(email, password, fullName)
+	user, err := domain.NewUser(email, password, fullName, u.passwords)
 	if err != nil {
 		return nil, err
 	}
@@ -43,51 +90,325 @@ func (u *userUsecase) Register(email, password, fullName string) (*domain.User,
 	return user, err
 }
 
-func (u *userUsecase) Login(email, password string) (string, error) {
+func (u *userUsecase) Login(email, password string) (*TokenPair, error) {
 	user, err := u.repo.GetByEmail(email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		// Pay the same hashing cost as for a real account so response times
+		// do not tell which emails are registered
+		_, _ = u.passwords.Hasher.Verify(u.dummyPasswordHash(), password)
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	// A locked account fails like a wrong password, so the answer does not
+	// tell registered emails apart; only the retry-after reveals the lock
+	if err := u.checkPassword(user, password, domain.ErrInvalidCredentials, domain.ErrInvalidCredentials); err != nil {
+		return nil, err
+	}
+	if user.Status == domain.StatusDisabled {
+		return nil, domain.ErrAccountDisabled
+	}
+
+	// Upgrade hashes made with an outdated algorithm or cost while the
+	// plaintext is at hand. The login itself succeeds either way.
+	if rehashed, err := user.RehashPassword(password, u.passwords.Hasher); err != nil {
+		log.Printf("Password rehash for user %d failed: %v", user.ID, err)
+	} else if rehashed {
+		if err := u.repo.Update(user); err != nil {
+			log.Printf("Saving rehashed password for user %d failed: %v", user.ID, err)
+		}
+	}
+
+	// Every login starts a new refresh token family
+	return u.issueTokens(user, uuid.New())
+}
+
+// dummyPasswordHash is a hash of a random password made with the current
+// hasher settings, computed on first use.
+func (u *userUsecase) dummyPasswordHash() string {
+	u.dummyHashOnce.Do(func() {
+		hashed, err := u.passwords.Hasher.Hash(uuid.NewString())
+		if err != nil {
+			log.Printf("Dummy password hash failed: %v", err)
+		}
+		u.dummyHash = hashed
+	})
+	return u.dummyHash
+}
+
+// checkPassword verifies a password the user typed, under the lockout
+// policy: a locked account returns locked before the password is checked, so
+// guesses during the lock cannot succeed, and a wrong password counts as a
+// failed login and returns failure. A correct one resets the count.
+func (u *userUsecase) checkPassword(user *domain.User, password string, failure, locked *apperr.Error) error {
+	if wait := user.LockedFor(time.Now()); wait > 0 {
+		// Take as long as a real check
+		_, _ = u.passwords.Hasher.Verify(u.dummyPasswordHash(), password)
+		return locked.WithRetryAfter(wait)
+	}
+	ok, err := user.CheckPassword(password, u.passwords.Hasher)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return u.passwordFailed(user.ID, failure)
+	}
+	if user.FailedLogins > 0 || user.LockedUntil != nil {
+		return u.repo.ResetFailedLogins(user.ID)
+	}
+	return nil
+}
+
+// passwordFailed counts a wrong password and locks the account as the
+// lockout policy demands. The client learns how long it has to wait.
+func (u *userUsecase) passwordFailed(userID int64, failure *apperr.Error) error {
+	failures, err := u.repo.RecordFailedLogin(userID)
+	if err != nil {
+		return err
+	}
+	lock := u.lockout.LockFor(failures)
+	if lock <= 0 {
+		return failure
+	}
+	if err := u.repo.LockUntil(userID, time.Now().Add(lock)); err != nil {
+		return err
+	}
+	return failure.WithRetryAfter(lock)
+}
+
+// RefreshToken rotates a refresh token: the presented token is consumed and a
+// new one from the same family is returned. Presenting an already used token
+// revokes the entire family, forcing the legitimate owner to log in again.
+func (u *userUsecase) RefreshToken(refreshToken string) (*TokenPair, error) {
+	stored, err := u.refreshRepo.GetByHash(domain.HashRefreshToken(refreshToken))
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	now := time.Now()
+	if stored.RevokedAt != nil || stored.IsExpired(now) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if stored.UsedAt != nil {
+		return nil, u.revokeFamily(stored)
+	}
+
+	consumed, err := u.refreshRepo.MarkUsed(stored.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		// Another request consumed the same token in the meantime
+		return nil, u.revokeFamily(stored)
+	}
+
+	user, err := u.repo.GetByID(stored.UserID)
+	if err != nil || user.Status == domain.StatusDisabled {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return u.issueTokens(user, stored.FamilyID)
+}
+
+// Logout revokes the access token identified by jti and, when supplied, the
+// refresh token family it belongs to.
+func (u *userUsecase) Logout(userID int64, jti string, expiresAt time.Time, refreshToken string) error {
+	if err := u.revocations.RevokeToken(jti, userID, expiresAt); err != nil {
+		return err
+	}
+	if refreshToken == "" {
+		return nil
+	}
+
+	stored, err := u.refreshRepo.GetByHash(domain.HashRefreshToken(refreshToken))
+	if err != nil || stored.UserID != userID {
+		// Unknown or foreign refresh tokens are ignored; the access token is already revoked
+		return nil
+	}
+	return u.refreshRepo.RevokeFamily(stored.FamilyID)
+}
+
+// RevokeAllSessions invalidates every outstanding access and refresh token of the user.
+func (u *userUsecase) RevokeAllSessions(userID int64) error {
+	if err := u.refreshRepo.RevokeAllForUser(userID); err != nil {
+		return err
+	}
+	return u.revocations.RevokeUser(userID)
+}
+
+func (u *userUsecase) revokeFamily(token *domain.RefreshToken) error {
+	if err := u.refreshRepo.RevokeFamily(token.FamilyID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+func (u *userUsecase) issueTokens(user *domain.User, familyID uuid.UUID) (*TokenPair, error) {
+	accessToken, err := u.generateJWT(user)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, plain, err := domain.NewRefreshToken(user.ID, familyID, u.tokens.RefreshTokenTTL)
 	if err != nil {
-		return "", errors.New("invalid email or password")
+		return nil, err
 	}
-	if !user.CheckPassword(password) {
-		return "", errors.New("invalid email or password")
+	if err := u.refreshRepo.Create(refresh); err != nil {
+		return nil, err
 	}
 
-	token, err := u.generateJWT(user)
-	return token, err
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: plain,
+		ExpiresIn:    u.tokens.AccessTokenTTL,
+	}, nil
 }
 
 func (u *userUsecase) generateJWT(user *domain.User) (string, error) {
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"user_id": user.ID,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(),
+		"roles":   user.RoleNames(),
+		"perms":   domain.PermissionsFor(user.RoleNames()),
+		"jti":     uuid.NewString(),
+		"iat":     now.Unix(),
+		"iat_ms":  now.UnixMilli(),
+		"exp":     now.Add(u.tokens.AccessTokenTTL).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(u.jwtSecret)
+	return u.tokens.Signer.Sign(claims)
 }
 
 func (u *userUsecase) Get(id int64) (*domain.User, error) {
 	return u.repo.GetByID(id)
 }
 
-func (u *userUsecase) Update(id int64, email, password, fullName string) (*domain.User, error) {
+func (u *userUsecase) Update(id int64, update domain.UserUpdate, currentPassword *string) (*domain.User, error) {
 	user, err := u.repo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
-	if err := user.Update(email, password, fullName); err != nil {
+	// Wrong guesses count towards the lockout like failed logins, so a
+	// stolen token cannot be used to brute-force the password either
+	if currentPassword != nil {
+		if *currentPassword == "" {
+			return nil, domain.ErrCurrentPasswordRequired
+		}
+		if err := u.checkPassword(user, *currentPassword, domain.ErrWrongCurrentPassword, domain.ErrAccountLocked); err != nil {
+			return nil, err
+		}
+	}
+	if err := user.Update(update, u.passwords); err != nil {
 		return nil, err
 	}
 	if err := u.repo.Update(user); err != nil {
 		return nil, err
 	}
+
+	// A password change must end every session opened with the old password,
+	// and a disabled account keeps none
+	if update.Password != nil || update.Status != nil && *update.Status == domain.StatusDisabled {
+		if err := u.RevokeAllSessions(user.ID); err != nil {
+			return nil, err
+		}
+	}
 	return user, nil
 }
 
+// Delete removes the user after revoking their sessions, so access tokens
+// already issued to the account stop working before they expire. Session
+// revocations are not tied to the users table and outlive the row.
 func (u *userUsecase) Delete(id int64) error {
+	if _, err := u.repo.GetByID(id); err != nil {
+		return err
+	}
+	if err := u.RevokeAllSessions(id); err != nil {
+		return err
+	}
 	return u.repo.Delete(id)
 }
 
-func (u *userUsecase) List() ([]*domain.User, error) {
-	return u.repo.List()
+// List returns one page of the users matching filter in sort order. A page
+// token continues after the previous page and must come from a request with
+// the same sort; without one query.Page selects the page by offset.
+func (u *userUsecase) List(filter domain.UserFilter, sort domain.UserSort, query pagination.Query) (*pagination.Page[*domain.User], error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	after, err := pagination.Decode(query.Token)
+	if err != nil {
+		return nil, err
+	}
+	if after != nil && after.Order != sort.String() {
+		return nil, pagination.ErrInvalidPageToken
+	}
+	limit := pagination.Limit(query.Limit)
+
+	// 1. Fetch one extra row to learn whether another page follows
+	rows, err := u.repo.List(filter, sort, after, query.Offset(), limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &pagination.Page[*domain.User]{}
+	page.Items, page.NextPageToken = pagination.Trim(rows, limit, func(user *domain.User) pagination.Cursor {
+		return userCursor(user, sort)
+	})
+	if !query.Keyset() {
+		page.Page = max(query.Page, 1)
+	}
+
+	// 2. Count only when asked for
+	if query.CountTotal() {
+		if page.Total, err = u.repo.Count(filter); err != nil {
+			return nil, err
+		}
+		page.Counted = true
+	}
+	return page, nil
+}
+
+func userCursor(user *domain.User, sort domain.UserSort) pagination.Cursor {
+	c := pagination.Cursor{CreatedAt: user.CreatedAt, ID: strconv.FormatInt(user.ID, 10), Order: sort.String()}
+	switch sort.Field {
+	case domain.SortEmail:
+		c.Key = user.Email
+	case domain.SortFullName:
+		c.Key = user.FullName
+	}
+	return c
+}
+
+func (u *userUsecase) GrantRole(userID int64, role string) (*domain.User, error) {
+	return u.changeRole(userID, role, u.repo.AddRole)
+}
+
+func (u *userUsecase) RevokeRole(userID int64, role string) (*domain.User, error) {
+	return u.changeRole(userID, role, u.repo.RemoveRole)
+}
+
+// Unlock lets a locked out user log in again right away.
+func (u *userUsecase) Unlock(id int64) (*domain.User, error) {
+	if err := u.repo.ResetFailedLogins(id); err != nil {
+		return nil, err
+	}
+	return u.repo.GetByID(id)
+}
+
+// changeRole applies a role change and revokes the user's sessions, since
+// their outstanding tokens still carry the previous roles.
+func (u *userUsecase) changeRole(userID int64, role string, apply func(int64, string) error) (*domain.User, error) {
+	if !domain.IsValidRole(role) {
+		return nil, domain.ErrInvalidRole
+	}
+	if _, err := u.repo.GetByID(userID); err != nil {
+		return nil, err
+	}
+	if err := apply(userID, role); err != nil {
+		return nil, err
+	}
+	if err := u.RevokeAllSessions(userID); err != nil {
+		return nil, err
+	}
+	return u.repo.GetByID(userID)
 }
@@ -0,0 +1,198 @@
+// Package validate enforces the (validate.rules) field options declared in
+// proto/validate.proto on incoming request messages.
+package validate
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/imimran/go-grpc-auth/apperr"
+	pb "github.com/imimran/go-grpc-auth/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var ErrInvalidRequest = apperr.InvalidArgument("INVALID_REQUEST", "invalid request")
+
+// Message checks msg and every set nested message against their field
+// rules. It returns nil or an ErrInvalidRequest listing all violations, the
+// first of which is also named in the message.
+func Message(msg proto.Message) error {
+	var violations []apperr.FieldViolation
+	check(msg.ProtoReflect(), "", &violations)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	first := violations[0]
+	err := ErrInvalidRequest.WithMessage("invalid request: %s %s", first.Field, first.Description)
+	if len(violations) > 1 {
+		err = err.WithMessage("%s (and %d more)", err.Message, len(violations)-1)
+	}
+	for _, v := range violations {
+		err = err.WithField(v.Field, v.Description)
+	}
+	return err
+}
+
+func check(m protoreflect.Message, prefix string, violations *[]apperr.FieldViolation) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+		rules := rulesFor(fd)
+
+		switch {
+		case fd.IsMap():
+			continue
+		case fd.IsList():
+			list := m.Get(fd).List()
+			if rules != nil {
+				if rules.GetRequired() && list.Len() == 0 {
+					add(violations, path, "is required")
+				}
+				if rules.GetMaxItems() > 0 && list.Len() > int(rules.GetMaxItems()) {
+					add(violations, path, fmt.Sprintf("must have at most %d items", rules.GetMaxItems()))
+				}
+			}
+			for j := 0; j < list.Len(); j++ {
+				itemPath := path + "[" + strconv.Itoa(j) + "]"
+				if fd.Message() != nil {
+					check(list.Get(j).Message(), itemPath+".", violations)
+				} else if rules != nil {
+					checkValue(fd, list.Get(j), rules, itemPath, violations)
+				}
+			}
+		case fd.Message() != nil:
+			if m.Has(fd) {
+				check(m.Get(fd).Message(), path+".", violations)
+			} else if rules.GetRequired() {
+				add(violations, path, "is required")
+			}
+		case rules == nil:
+		case fd.HasPresence() && !m.Has(fd):
+			// Unset optional scalars and oneof members are only checked by required
+			if rules.GetRequired() {
+				add(violations, path, "is required")
+			}
+		default:
+			checkValue(fd, m.Get(fd), rules, path, violations)
+		}
+	}
+}
+
+func rulesFor(fd protoreflect.FieldDescriptor) *pb.FieldRules {
+	opts := fd.Options()
+	if opts == nil || !proto.HasExtension(opts, pb.E_Rules) {
+		return nil
+	}
+	return proto.GetExtension(opts, pb.E_Rules).(*pb.FieldRules)
+}
+
+func checkValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, rules *pb.FieldRules, path string, violations *[]apperr.FieldViolation) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		checkString(v.String(), rules, path, violations)
+	case protoreflect.BytesKind:
+		b := v.Bytes()
+		if len(b) == 0 {
+			if rules.GetRequired() {
+				add(violations, path, "is required")
+			}
+			return
+		}
+		if rules.GetMaxBytes() > 0 && len(b) > int(rules.GetMaxBytes()) {
+			add(violations, path, fmt.Sprintf("must be at most %d bytes", rules.GetMaxBytes()))
+		}
+	case protoreflect.BoolKind, protoreflect.EnumKind:
+	default:
+		checkNumber(toFloat(fd.Kind(), v), rules, path, violations)
+	}
+}
+
+func checkString(s string, rules *pb.FieldRules, path string, violations *[]apperr.FieldViolation) {
+	if s == "" {
+		if rules.GetRequired() {
+			add(violations, path, "is required")
+		}
+		return
+	}
+
+	n := utf8.RuneCountInString(s)
+	switch {
+	case rules.GetMinLen() > 0 && n < int(rules.GetMinLen()):
+		add(violations, path, fmt.Sprintf("must be at least %d characters", rules.GetMinLen()))
+	case rules.GetMaxLen() > 0 && n > int(rules.GetMaxLen()):
+		add(violations, path, fmt.Sprintf("must be at most %d characters", rules.GetMaxLen()))
+	case rules.GetMaxBytes() > 0 && len(s) > int(rules.GetMaxBytes()):
+		add(violations, path, fmt.Sprintf("must be at most %d bytes", rules.GetMaxBytes()))
+	}
+
+	switch rules.GetFormat() {
+	case "email":
+		if !isEmail(s) {
+			add(violations, path, "must be a valid email address")
+		}
+	case "uuid":
+		if _, err := uuid.Parse(s); err != nil || len(s) != 36 {
+			add(violations, path, "must be a UUID")
+		}
+	}
+
+	if in := rules.GetIn(); len(in) > 0 && !contains(in, s) {
+		add(violations, path, "must be one of "+strings.Join(in, ", "))
+	}
+}
+
+func checkNumber(x float64, rules *pb.FieldRules, path string, violations *[]apperr.FieldViolation) {
+	switch {
+	case math.IsNaN(x) && (rules.Gt != nil || rules.Gte != nil || rules.Lte != nil):
+		add(violations, path, "must be a number")
+	case rules.Gt != nil && x <= rules.GetGt():
+		add(violations, path, "must be greater than "+formatFloat(rules.GetGt()))
+	case rules.Gte != nil && rules.Lte != nil && (x < rules.GetGte() || x > rules.GetLte()):
+		add(violations, path, "must be between "+formatFloat(rules.GetGte())+" and "+formatFloat(rules.GetLte()))
+	case rules.Gte != nil && x < rules.GetGte():
+		add(violations, path, "must be at least "+formatFloat(rules.GetGte()))
+	case rules.Lte != nil && x > rules.GetLte():
+		add(violations, path, "must be at most "+formatFloat(rules.GetLte()))
+	}
+}
+
+// isEmail accepts a bare address ("a@b.example"), not a display name form
+func isEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s && strings.Contains(s[strings.LastIndex(s, "@"):], ".")
+}
+
+func toFloat(kind protoreflect.Kind, v protoreflect.Value) float64 {
+	switch kind {
+	case protoreflect.DoubleKind, protoreflect.FloatKind:
+		return v.Float()
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return float64(v.Uint())
+	}
+	return float64(v.Int())
+}
+
+func formatFloat(x float64) string {
+	return strconv.FormatFloat(x, 'f', -1, 64)
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func add(violations *[]apperr.FieldViolation, field, description string) {
+	*violations = append(*violations, apperr.FieldViolation{Field: field, Description: description})
+}